@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/report"
+	"web-analyzer/models"
+)
+
+// target is a page to analyse: a live URL, or a local document read from path
+// ("-" for stdin) whose links resolve against baseURL.
+type target struct {
+	name    string
+	url     string
+	path    string
+	baseURL string
+}
+
+// runAnalyze analyses every URL, HTML file or directory of HTML files given on
+// the command line. "-" reads a document from stdin.
+func runAnalyze(args []string, stdout, stderr io.Writer) int {
+	var opts options
+	var baseURL string
+	var fetchResources bool
+	flags := newFlagSet("analyze", "<url|file|dir|->...", stderr)
+	opts.register(flags)
+	flags.StringVar(&baseURL, "base-url", "", "URL local documents are served from, to resolve and check their relative links")
+	flags.BoolVar(&fetchResources, "fetch-resources", false, "fetch the subresources of pages to measure their weight")
+	format, code, ok := opts.parse(flags, args, 1)
+	if !ok {
+		return code
+	}
+	targets, err := expandTargets(flags.Args(), baseURL)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	ctx, cancel := opts.newContext()
+	defer cancel()
+
+	service := newService(fetchResources)
+	suite := report.Suite{Name: "analyze"}
+	for _, t := range targets {
+		start := time.Now()
+		result, err := t.analyse(ctx, service)
+		var failure string
+		if err != nil {
+			failure = err.Error()
+		}
+		suite.Cases = append(suite.Cases, resultCase(t.name, result, failure, opts.thresholds, time.Since(start)))
+	}
+
+	if err := report.Write(stdout, format, resultColumns, suite, resultRow); err != nil {
+		return exitError
+	}
+	return exitCode(suite)
+}
+
+func (t target) analyse(ctx context.Context, service analyzer.AnalyzerService) (*models.AnalysisResult, error) {
+	if t.url != "" {
+		if err := service.AnalyzePage(ctx, t.url); err != nil {
+			return nil, err
+		}
+		result, ok := service.GetAnalysis(t.url)
+		if !ok {
+			return nil, nil
+		}
+		return &result, nil
+	}
+
+	var r io.Reader = os.Stdin
+	if t.path != "-" {
+		f, err := os.Open(t.path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	result, err := service.AnalyzeDocument(ctx, r, t.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// expandTargets turns command line arguments into targets. Directories are
+// searched for .html and .htm files, whose base URLs are baseURL joined with
+// their path relative to the directory.
+func expandTargets(args []string, baseURL string) ([]target, error) {
+	var targets []target
+	for _, arg := range args {
+		if arg == "-" {
+			targets = append(targets, target{name: "stdin", path: "-", baseURL: baseURL})
+			continue
+		}
+		if strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") {
+			targets = append(targets, target{name: arg, url: arg})
+			continue
+		}
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			targets = append(targets, target{name: arg, path: arg, baseURL: baseURL})
+			continue
+		}
+		err = filepath.WalkDir(arg, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if ext := strings.ToLower(filepath.Ext(p)); ext != ".html" && ext != ".htm" {
+				return nil
+			}
+			rel, err := filepath.Rel(arg, p)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, target{name: p, path: p, baseURL: joinURL(baseURL, filepath.ToSlash(rel))})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return targets, nil
+}
+
+// joinURL appends rel to the path of base. It returns an empty string when
+// base is empty, so relative links stay unchecked.
+func joinURL(base, rel string) string {
+	if base == "" {
+		return ""
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+	u.Path = path.Join("/", u.Path, rel)
+	return u.String()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/report"
+)
+
+// runCheckLinks checks every link listed in a file. Blank lines and lines
+// starting with # are ignored.
+func runCheckLinks(args []string, stdout, stderr io.Writer) int {
+	var opts options
+	var concurrency int
+	fs := newFlagSet("check-links", "<file>", stderr)
+	opts.register(fs)
+	fs.IntVar(&concurrency, "concurrency", 8, "links checked at once")
+	format, code, ok := opts.parse(fs, args, 1)
+	if !ok {
+		return code
+	}
+	if fs.NArg() != 1 || concurrency < 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	links, err := readLinks(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	ctx, cancel := opts.newContext()
+	defer cancel()
+
+	checker := newLinkChecker()
+	suite := report.Suite{Name: "check-links", Cases: make([]report.Case, len(links))}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, link := range links {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			start := time.Now()
+			c := report.Case{Name: link}
+			if checker.IsBroken(ctx, link) {
+				c.Failures = []string{"link is broken"}
+			}
+			c.Duration = time.Since(start)
+			suite.Cases[i] = c
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		suite.Cases = append(suite.Cases, report.Case{Name: fs.Arg(0), Error: "check interrupted: " + err.Error()})
+	}
+
+	row := func(c report.Case) []string { return []string{c.Name} }
+	if err := report.Write(stdout, format, []string{"LINK"}, suite, row); err != nil {
+		return exitError
+	}
+	failures, errors := suite.Counts()
+	switch {
+	case errors > 0:
+		return exitError
+	case opts.thresholds.MaxBrokenLinks >= 0 && failures > opts.thresholds.MaxBrokenLinks:
+		return exitThreshold
+	}
+	return exitOK
+}
+
+// readLinks reads one link per line from path, or from stdin when path is "-".
+func readLinks(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var links []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		links = append(links, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return links, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/report"
+)
+
+// runCrawl analyses a site starting from one URL.
+func runCrawl(args []string, stdout, stderr io.Writer) int {
+	var opts options
+	var crawlOpts crawler.Options
+	var fetchResources bool
+	fs := newFlagSet("crawl", "<url>", stderr)
+	opts.register(fs)
+	fs.IntVar(&crawlOpts.MaxPages, "max-pages", crawler.DefaultMaxPages, "maximum number of pages to analyse")
+	fs.IntVar(&crawlOpts.MaxDepth, "depth", crawler.DefaultMaxDepth, "maximum number of links to follow from the start page")
+	fs.IntVar(&crawlOpts.Concurrency, "concurrency", crawler.DefaultConcurrency, "pages analysed at once")
+	fs.BoolVar(&fetchResources, "fetch-resources", false, "fetch the subresources of pages to measure their weight")
+	format, code, ok := opts.parse(fs, args, 1)
+	if !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	ctx, cancel := opts.newContext()
+	defer cancel()
+
+	pages, err := crawler.New(newService(fetchResources), crawlOpts).Crawl(ctx, fs.Arg(0))
+	if errors.Is(err, crawler.ErrInvalidURL) {
+		fmt.Fprintf(stderr, "%v: %s\n", err, fs.Arg(0))
+		return exitUsage
+	}
+
+	suite := report.Suite{Name: "crawl"}
+	depths := make(map[string]int, len(pages))
+	for _, page := range pages {
+		depths[page.URL] = page.Depth
+		suite.Cases = append(suite.Cases, resultCase(page.URL, page.Result, page.Error, opts.thresholds, 0))
+	}
+	if err != nil {
+		suite.Cases = append(suite.Cases, report.Case{Name: fs.Arg(0), Error: "crawl interrupted: " + err.Error()})
+	}
+
+	columns := append([]string{"DEPTH"}, resultColumns...)
+	row := func(c report.Case) []string {
+		depth := "-"
+		if d, ok := depths[c.Name]; ok {
+			depth = strconv.Itoa(d)
+		}
+		return append([]string{depth}, resultRow(c)...)
+	}
+	if err := report.Write(stdout, format, columns, suite, row); err != nil {
+		return exitError
+	}
+	return exitCode(suite)
+}
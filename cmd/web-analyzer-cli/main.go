@@ -0,0 +1,186 @@
+// Command web-analyzer-cli runs analyses in-process, without a server, for
+// one-off audits and CI pipelines. It exits with a non-zero status when a
+// result crosses a threshold, so builds can be gated on it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"web-analyzer/internal/analysis"
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/linkchecker"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/performance"
+	"web-analyzer/internal/report"
+	services "web-analyzer/internal/storage"
+	"web-analyzer/models"
+)
+
+// Exit codes.
+const (
+	exitOK = 0
+	// exitThreshold means every check ran but some crossed a threshold.
+	exitThreshold = 1
+	// exitUsage means the command line was invalid.
+	exitUsage = 2
+	// exitError means some checks could not run, e.g. a page failed to load.
+	exitError = 3
+)
+
+const usage = `Usage: web-analyzer-cli <command> [flags] <args>
+
+Commands:
+  analyze <target>...   analyse URLs, HTML files, directories of HTML files or stdin ("-")
+  crawl <url>           analyse a site by following its internal links
+  check-links <file>    check the links listed in a file, one per line ("-" reads stdin)
+
+Run "web-analyzer-cli <command> -h" for the flags of a command.
+
+Exit status is 0 when all checks pass, 1 when a threshold is crossed, 2 for
+usage errors and 3 when a check could not run.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	commands := map[string]func([]string, io.Writer, io.Writer) int{
+		"analyze":     runAnalyze,
+		"crawl":       runCrawl,
+		"check-links": runCheckLinks,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		if args[0] != "-h" && args[0] != "-help" && args[0] != "help" {
+			fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		}
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	return cmd(args[1:], stdout, stderr)
+}
+
+// options are the flags shared by all commands.
+type options struct {
+	format     string
+	thresholds report.Thresholds
+	timeout    time.Duration
+	verbose    bool
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.format, "format", "table", "output format: table, json or junit")
+	fs.IntVar(&o.thresholds.MaxBrokenLinks, "max-broken-links", 0, "fail when more links are broken, -1 to disable")
+	fs.IntVar(&o.thresholds.MinScore, "min-score", 0, "fail when a page scores lower")
+	fs.DurationVar(&o.timeout, "timeout", 5*time.Minute, "overall time limit")
+	fs.BoolVar(&o.verbose, "v", false, "log progress to stderr")
+}
+
+// parse parses the flags of a command, returning the exit code to use when it
+// should not run.
+func (o *options) parse(fs *flag.FlagSet, args []string, minArgs int) (report.Format, int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return "", exitOK, false
+		}
+		return "", exitUsage, false
+	}
+	format, err := report.ParseFormat(o.format)
+	if err != nil {
+		fmt.Fprintln(fs.Output(), err)
+		return "", exitUsage, false
+	}
+	if fs.NArg() < minArgs {
+		fs.Usage()
+		return "", exitUsage, false
+	}
+	level := slog.LevelWarn
+	if o.verbose {
+		level = slog.LevelInfo
+	}
+	logging.SetLevel(level)
+	slog.SetDefault(logging.New(fs.Output()))
+	return format, exitOK, true
+}
+
+// newContext returns a context bounded by the timeout and cancelled on
+// interrupt.
+func (o *options) newContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+func newFlagSet(name, args string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: web-analyzer-cli %s [flags] %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func newLinkChecker() analyzer.LinkChecker {
+	return linkchecker.NewCachingLinkChecker(linkchecker.NewLinkChecker(), 10*time.Minute)
+}
+
+// newService returns an analyzer service keeping its results in memory for the
+// duration of the command. fetchResources fetches the subresources of pages to
+// measure their weight.
+func newService(fetchResources bool) analyzer.AnalyzerService {
+	return &analyzer.DefaultAnalyzerService{Analyzer: &analyzer.Analyzer{
+		Storage:     services.NewStorage(),
+		LinkChecker: newLinkChecker(),
+		Analysis:    analysis.NewAnalysis(),
+		Performance: performance.Options{FetchResources: fetchResources},
+	}}
+}
+
+// exitCode returns the exit status of a finished suite.
+func exitCode(suite report.Suite) int {
+	failures, errors := suite.Counts()
+	switch {
+	case errors > 0:
+		return exitError
+	case failures > 0:
+		return exitThreshold
+	}
+	return exitOK
+}
+
+// resultColumns are the table columns of analysed pages.
+var resultColumns = []string{"URL", "STATUS", "SCORE", "BROKEN LINKS", "TITLE"}
+
+func resultRow(c report.Case) []string {
+	if c.Result == nil {
+		return []string{c.Name, "Failed", "-", "-", ""}
+	}
+	return []string{c.Name, c.Result.Status, strconv.Itoa(c.Result.Score), strconv.Itoa(c.Result.BrokenLinks), c.Result.Title}
+}
+
+// resultCase builds the report case of an analysed page.
+func resultCase(name string, result *models.AnalysisResult, err string, thresholds report.Thresholds, took time.Duration) report.Case {
+	c := report.Case{Name: name, Result: result, Error: err, Duration: took}
+	if result != nil && err == "" {
+		c.Failures = thresholds.Violations(*result)
+	}
+	return c
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newSite serves a page without problems at /, a page with a broken link at
+// /broken and nothing else.
+func newSite(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`<!DOCTYPE html><html><head><title>Home</title></head><body><h1>Home</h1><a href="/broken">Broken</a></body></html>`))
+		case "/broken":
+			w.Write([]byte(`<!DOCTYPE html><html><head><title>Broken</title></head><body><h1>Broken</h1><a href="/missing">Missing</a></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeLinks(t *testing.T, links ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "links.txt")
+	if err := os.WriteFile(path, []byte("# links\n\n"+strings.Join(links, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	site := newSite(t)
+	links := writeLinks(t, site.URL+"/", site.URL+"/missing")
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+		// output, when set, must appear on stdout.
+		output string
+	}{
+		{"no command", nil, exitUsage, ""},
+		{"unknown command", []string{"lint"}, exitUsage, ""},
+		{"help", []string{"analyze", "-h"}, exitOK, ""},
+		{"missing target", []string{"analyze"}, exitUsage, ""},
+		{"unknown format", []string{"analyze", "-format=xml", site.URL + "/"}, exitUsage, ""},
+		{"passing page", []string{"analyze", site.URL + "/"}, exitOK, "Home"},
+		{"broken link", []string{"analyze", site.URL + "/broken"}, exitThreshold, "1 failed"},
+		{"broken links allowed", []string{"analyze", "-max-broken-links=1", site.URL + "/broken"}, exitOK, ""},
+		{"broken links check disabled", []string{"analyze", "-max-broken-links=-1", site.URL + "/broken"}, exitOK, ""},
+		{"score below minimum", []string{"analyze", "-min-score=101", site.URL + "/"}, exitThreshold, ""},
+		{"score above minimum", []string{"analyze", "-min-score=1", site.URL + "/"}, exitOK, ""},
+		{"page not found", []string{"analyze", site.URL + "/gone"}, exitError, "1 errors"},
+		{"crawl", []string{"crawl", "-depth=1", "-max-broken-links=-1", site.URL + "/"}, exitOK, "/broken"},
+		{"crawl with broken link", []string{"crawl", "-depth=1", site.URL + "/"}, exitThreshold, ""},
+		// At depth 2 the crawl reaches the missing page, which fails to load.
+		{"crawl with missing page", []string{"crawl", "-depth=2", site.URL + "/"}, exitError, "/missing"},
+		{"crawl invalid URL", []string{"crawl", "example.com"}, exitUsage, ""},
+		{"check links", []string{"check-links", links}, exitThreshold, ""},
+		{"check links within threshold", []string{"check-links", "-max-broken-links=1", links}, exitOK, ""},
+		{"check links disabled", []string{"check-links", "-max-broken-links=-1", links}, exitOK, ""},
+		{"check links missing file", []string{"check-links", filepath.Join(t.TempDir(), "none.txt")}, exitUsage, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(tt.args, &stdout, &stderr); got != tt.want {
+				t.Errorf("run(%q) = %d; want %d\nstdout: %s\nstderr: %s", tt.args, got, tt.want, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.output) {
+				t.Errorf("expected %q in output, got:\n%s", tt.output, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	site := newSite(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"analyze", "-format=json", site.URL + "/broken"}, &stdout, &stderr); code != exitThreshold {
+		t.Fatalf("exit code = %d; want %d\n%s", code, exitThreshold, stderr.String())
+	}
+	var suite struct {
+		Cases []struct {
+			Name     string   `json:"name"`
+			Failures []string `json:"failures"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &suite); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(suite.Cases) != 1 || len(suite.Cases[0].Failures) != 1 {
+		t.Errorf("expected one case with one failure, got %+v", suite.Cases)
+	}
+}
+
+func TestRun_CheckLinksJUnit(t *testing.T) {
+	site := newSite(t)
+	links := writeLinks(t, site.URL+"/", site.URL+"/missing")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"check-links", "-format=junit", links}, &stdout, &stderr); code != exitThreshold {
+		t.Fatalf("exit code = %d; want %d\n%s", code, exitThreshold, stderr.String())
+	}
+	var suites struct {
+		Suites []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Cases    []struct {
+				Name    string    `xml:"name,attr"`
+				Failure *struct{} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(stdout.Bytes(), &suites); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, stdout.String())
+	}
+	if len(suites.Suites) != 1 {
+		t.Fatalf("expected one suite, got %+v", suites.Suites)
+	}
+	suite := suites.Suites[0]
+	if suite.Name != "check-links" || suite.Tests != 2 || suite.Failures != 1 {
+		t.Errorf("unexpected suite: %+v", suite)
+	}
+	for _, c := range suite.Cases {
+		if broken := strings.HasSuffix(c.Name, "/missing"); broken != (c.Failure != nil) {
+			t.Errorf("case %s: failure = %v", c.Name, c.Failure != nil)
+		}
+	}
+}
@@ -1,57 +1,344 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
-	_ "net/http/pprof"
+	"net/netip"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"web-analyzer/handlers"
 	"web-analyzer/internal/analysis"
 	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/buildinfo"
+	"web-analyzer/internal/debugserver"
+	"web-analyzer/internal/fingerprint"
+	"web-analyzer/internal/grpcserver"
+	"web-analyzer/internal/health"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/metrics"
+	"web-analyzer/internal/performance"
+	"web-analyzer/internal/ratelimit"
+	"web-analyzer/internal/scheduler"
 	"web-analyzer/internal/server"
+	"web-analyzer/internal/tracing"
+	"web-analyzer/internal/workspace"
 
 	"web-analyzer/internal/linkchecker"
 	services "web-analyzer/internal/storage"
 
-	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
 	"github.com/rs/cors"
+	"google.golang.org/grpc"
 )
 
+// shutdownTimeout bounds how long in-flight requests may take to finish once a
+// shutdown signal arrives.
+const shutdownTimeout = 15 * time.Second
+
 func main() {
-	// Create instances of Storage and LinkChecker
-	storage := services.NewStorage()
-	linkChecker := linkchecker.NewLinkChecker()
-	analysis := analysis.NewAnalysis() // Pass storage to analysis
+	logger := logging.New(os.Stdout)
+	slog.SetDefault(logger)
+	if env := os.Getenv("LOG_LEVEL"); env != "" {
+		level, err := logging.ParseLevel(env)
+		if err != nil {
+			logger.Error("Invalid LOG_LEVEL", "error", err)
+			os.Exit(1)
+		}
+		logging.SetLevel(level)
+	}
+	// Requests are logged through slog, so keep gin's own output quiet.
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
 
-	// Pass the required arguments to NewAnalyzerService
-	analyzerService := analyzer.NewAnalyzerService(*storage, linkChecker, *analysis)
-	h := handlers.NewHandler(analyzerService)
+	fingerprints := fingerprint.Default()
+	if file := os.Getenv("FINGERPRINT_RULES_FILE"); file != "" {
+		var err error
+		if fingerprints, err = fingerprint.LoadFile(file); err != nil {
+			logger.Error("Failed to load fingerprint rules", "file", file, "error", err)
+			os.Exit(1)
+		}
+	}
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	slog.SetDefault(logger)
+	fetchResources, _ := strconv.ParseBool(os.Getenv("FETCH_RESOURCES"))
+	perf := performance.Options{
+		FetchResources:   fetchResources,
+		MaxResourceBytes: int64(envInt("RESOURCE_MAX_BYTES", 0)),
+		MaxTotalBytes:    int64(envInt("PAGE_WEIGHT_MAX_BYTES", 0)),
+		MaxRequests:      envInt("PAGE_WEIGHT_MAX_REQUESTS", 0),
+	}
+
+	// The link checker, its optional cache and the page client are shared,
+	// while every workspace gets its own Storage and Analysis so their URLs
+	// and results stay apart. The client's timeout bounds every page and
+	// subresource fetch.
+	client := &http.Client{Timeout: time.Duration(envInt("FETCH_TIMEOUT_SECONDS", 30)) * time.Second}
+	var linkChecker linkchecker.LinkChecker = linkchecker.NewLinkChecker()
+	if ttl := envInt("LINK_CACHE_MINUTES", 0); ttl > 0 {
+		cache := linkchecker.NewCachingLinkChecker(linkChecker, time.Duration(ttl)*time.Minute)
+		cache.MaxEntries = envInt("LINK_CACHE_ENTRIES", linkchecker.DefaultMaxCacheEntries)
+		linkChecker = cache
+	}
+	workspaces := workspace.NewRegistry(func(string) analyzer.AnalyzerService {
+		return &analyzer.DefaultAnalyzerService{Analyzer: &analyzer.Analyzer{
+			Storage:      services.NewStorage(),
+			LinkChecker:  linkChecker,
+			Analysis:     analysis.NewAnalysis(),
+			HTTPClient:   client,
+			Fingerprints: fingerprints,
+			Performance:  perf,
+		}}
+	}, workspace.Quota{
+		AnalysesPerDay: envInt("WORKSPACE_ANALYSES_PER_DAY", 0),
+		CrawlPages:     envInt("WORKSPACE_CRAWL_PAGES", 0),
+	})
+	workspaces.MaxCrawls = envInt("WORKSPACE_CONCURRENT_CRAWLS", workspace.DefaultMaxCrawls)
+	quotasFile := os.Getenv("QUOTAS_FILE")
+	if quotasFile == "" {
+		quotasFile = "data/quotas.json"
+	}
+	if err := workspaces.LoadQuotas(quotasFile); err != nil {
+		logger.Error("Failed to load quotas", "file", quotasFile, "error", err)
+		os.Exit(1)
+	}
+	h := handlers.NewWorkspaceHandler(workspaces)
+	h.Health.Add("quotas", health.Writable(filepath.Dir(quotasFile)))
+	h.Jobs.Retention = time.Duration(envInt("JOB_RETENTION_MINUTES", int(jobs.DefaultRetention/time.Minute))) * time.Minute
+	metrics.RegisterQueueDepth(h.Jobs.QueueDepth)
+
+	h.Webhooks.CallbackSecret = os.Getenv("WEBHOOK_SECRET")
+	if h.Webhooks.CallbackSecret == "" {
+		logger.Warn("WEBHOOK_SECRET is not set, analyses with a callback_url are rejected")
+	}
+	webhooksFile := os.Getenv("WEBHOOKS_FILE")
+	if webhooksFile == "" {
+		webhooksFile = "data/webhooks.json"
+	}
+	if err := h.Webhooks.Load(webhooksFile); err != nil {
+		logger.Error("Failed to load webhooks", "file", webhooksFile, "error", err)
+		os.Exit(1)
+	}
+	h.Health.Add("webhooks", health.Writable(filepath.Dir(webhooksFile)))
 
-	// Initialize handlers
-	// h is already initialized with NewHandler
+	schedulesFile := os.Getenv("SCHEDULES_FILE")
+	if schedulesFile == "" {
+		schedulesFile = "data/schedules.json"
+	}
+	sched, err := scheduler.New(schedulesFile, h.Jobs)
+	if err != nil {
+		logger.Error("Failed to load schedules", "file", schedulesFile, "error", err)
+		os.Exit(1)
+	}
+	sched.Crawler = h
+	h.Scheduler = sched
+	h.Health.Add("schedules", health.Writable(filepath.Dir(schedulesFile)))
+
+	if err := setupAuth(logger, h); err != nil {
+		logger.Error("Failed to set up authentication", "error", err)
+		os.Exit(1)
+	}
 
-	// Pass the handler to SetupRouter
 	r := server.SetupRouter(h)
 
-	pprof.Register(r)
+	// The dashboard is served from the same origin. CORS is only needed for
+	// separately hosted frontends, listed in CORS_ALLOWED_ORIGINS.
+	var finalHandler http.Handler = r
+	if origins := envList("CORS_ALLOWED_ORIGINS"); len(origins) > 0 {
+		corsMiddleware := cors.New(cors.Options{
+			AllowedOrigins: origins,
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", auth.APIKeyHeader},
+			ExposedHeaders: []string{
+				ratelimit.LimitHeader, ratelimit.RemainingHeader, ratelimit.ResetHeader, ratelimit.RetryAfterHeader,
+			},
+			AllowCredentials: true,
+		})
+		finalHandler = corsMiddleware.Handler(r)
+	}
 
-	// Enable CORS
-	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:3000"}, // Adjust based on frontend
-		AllowedMethods:   []string{"GET", "POST"},
-		AllowedHeaders:   []string{"Content-Type"},
-		AllowCredentials: true,
-	})
+	// Tracing is set up last so that no exit below skips flushing it.
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.ConfigFromEnv())
+	if err != nil {
+		logger.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+	err = serve(logger, h, sched, finalHandler)
+	if err := shutdownTracing(context.Background()); err != nil {
+		logger.Error("Failed to flush traces", "error", err)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
 
-	finalHandler := corsMiddleware.Handler(r)
+// serve runs the HTTP, gRPC and debug servers and the scheduler until SIGINT
+// or SIGTERM arrives, then stops them, letting in-flight requests finish for
+// up to shutdownTimeout.
+func serve(logger *slog.Logger, h *handlers.Handler, sched *scheduler.Scheduler, handler http.Handler) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	logger.Info("Server started on :8080")
-	err := http.ListenAndServe(":8080", finalHandler)
+	debugSrv, err := debugserver.Start(debugserver.ConfigFromEnv())
+	if err != nil {
+		logger.Error("Failed to start debug server", "error", err)
+		return err
+	}
+	grpcSrv, err := startGRPC(logger, h)
 	if err != nil {
+		logger.Error("Failed to start gRPC server", "error", err)
+		return err
+	}
+	go sched.Run(ctx)
+	defer h.Webhooks.Close()
+
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	failed := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+	}()
+	build := buildinfo.Get()
+	logger.Info("Server started on :8080", "version", build.Version, "commit", build.Commit)
+
+	select {
+	case err = <-failed:
 		logger.Error("Server failed to start", "error", err)
+	case <-ctx.Done():
+		logger.Info("Shutting down")
+	}
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down server", "error", err)
+	}
+	if debugSrv != nil {
+		debugSrv.Shutdown(shutdownCtx)
+	}
+	if grpcSrv != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcSrv.Stop()
+		}
+	}
+	return err
+}
+
+// setupAuth enables API key authentication and rate limits. API_KEYS_FILE
+// (default data/api_keys.json) stores the key hashes and ADMIN_API_KEY
+// bootstraps a superadmin key. AUTH_DISABLED=true turns authentication off for
+// local development. TRUSTED_PROXIES lists the proxies whose X-Forwarded-For
+// header sets the client IP that requests are rate limited by, and
+// SECURE_COOKIES=true marks the dashboard's cookie Secure behind a TLS
+// terminating proxy.
+func setupAuth(logger *slog.Logger, h *handlers.Handler) error {
+	h.KeyLimiter = ratelimit.New(envFloat("RATE_LIMIT_KEY_RPS", 5), envInt("RATE_LIMIT_KEY_BURST", 20))
+	h.IPLimiter = ratelimit.New(envFloat("RATE_LIMIT_IP_RPS", 10), envInt("RATE_LIMIT_IP_BURST", 40))
+	h.TrustedProxies = envList("TRUSTED_PROXIES")
+	h.SecureCookies, _ = strconv.ParseBool(os.Getenv("SECURE_COOKIES"))
+	for _, proxy := range h.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				return fmt.Errorf("invalid TRUSTED_PROXIES entry %q", proxy)
+			}
+		}
+	}
+
+	if disabled, _ := strconv.ParseBool(os.Getenv("AUTH_DISABLED")); disabled {
+		logger.Warn("AUTH_DISABLED is set, API requests are not authenticated")
+		return nil
+	}
+	keysFile := os.Getenv("API_KEYS_FILE")
+	if keysFile == "" {
+		keysFile = "data/api_keys.json"
+	}
+	store, err := auth.NewStore(keysFile)
+	if err != nil {
+		return err
+	}
+	if bootstrap := os.Getenv("ADMIN_API_KEY"); bootstrap != "" {
+		if _, err := store.Ensure("bootstrap", bootstrap, []auth.Scope{auth.ScopeSuperAdmin}); err != nil {
+			return err
+		}
+	}
+	if len(store.List()) == 0 {
+		logger.Warn("No API keys exist, set ADMIN_API_KEY to bootstrap a superadmin key")
+	}
+	h.Auth = store
+	h.Health.Add("api_keys", health.Writable(filepath.Dir(keysFile)))
+	return nil
+}
+
+// startGRPC serves the gRPC API on GRPC_ADDR (default :9090) in the
+// background. GRPC_ADDR=off disables it, and the returned server is nil.
+func startGRPC(logger *slog.Logger, h *handlers.Handler) (*grpc.Server, error) {
+	addr := os.Getenv("GRPC_ADDR")
+	if addr == "off" {
+		return nil, nil
+	}
+	if addr == "" {
+		addr = ":9090"
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := grpcserver.New(h)
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			logger.Error("gRPC server stopped", "error", err)
+		}
+	}()
+	logger.Info("gRPC server started", "addr", lis.Addr().String())
+	return srv, nil
+}
+
+func envFloat(name string, fallback float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+func envInt(name string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+// envList returns the comma separated, non-empty values of an environment
+// variable.
+func envList(name string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
 	}
+	return values
 }
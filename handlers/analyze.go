@@ -3,11 +3,18 @@
 package handlers
 
 import (
-	"log/slog"
+	"errors"
 	"net/http"
 	"regexp"
 
 	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/health"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/ratelimit"
+	"web-analyzer/internal/scheduler"
+	"web-analyzer/internal/webhooks"
+	"web-analyzer/internal/workspace"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,49 +22,153 @@ import (
 // AnalyzeRequest represents the structure of the request body for analyzing a URL.
 type AnalyzeRequest struct {
 	URL string `json:"url"`
+	// CallbackURL optionally receives a signed webhook when the analysis finishes.
+	// It is rejected unless a callback secret is configured.
+	CallbackURL string `json:"callback_url,omitempty"`
 }
 
 // Handler provides HTTP handlers for URL analysis operations.
 // It includes methods for analyzing URLs, retrieving submitted URLs, and checking the status of URL analyses.
 type Handler struct {
-	AnalyzerService analyzer.AnalyzerService
+	// Workspaces holds the analyzer service and quotas of every workspace.
+	Workspaces *workspace.Registry
+	Jobs       *jobs.Manager
+	Webhooks   *webhooks.Dispatcher
+	// Scheduler manages recurring analyses. Schedule endpoints respond with
+	// 503 when it is nil.
+	Scheduler *scheduler.Scheduler
+	// Health holds the readiness checks reported on /readyz.
+	Health *health.Checker
+	// Auth holds the API keys. Requests are not authenticated when it is nil.
+	Auth *auth.Store
+	// KeyLimiter and IPLimiter rate limit requests per API key and per client
+	// IP. A nil limiter disables the corresponding limit.
+	KeyLimiter *ratelimit.Limiter
+	IPLimiter  *ratelimit.Limiter
+	// TrustedProxies lists the IPs and CIDRs of reverse proxies whose
+	// X-Forwarded-For header is believed. The client IP of every other request
+	// is its remote address.
+	TrustedProxies []string
+	// SecureCookies marks the dashboard's cookie Secure even when requests
+	// arrive over plain HTTP, as they do behind a TLS terminating proxy.
+	SecureCookies bool
 }
 
 // NewHandler creates a new instance of Handler with the provided AnalyzerService.
-// It is used to initialize the HTTP handlers for URL analysis operations.
+// All workspaces share the service, which suits single-tenant setups; use
+// NewWorkspaceHandler to isolate workspaces from each other.
 func NewHandler(analyzerService analyzer.AnalyzerService) *Handler {
+	return NewWorkspaceHandler(workspace.Static(analyzerService))
+}
+
+// NewWorkspaceHandler creates a new instance of Handler serving the workspaces
+// of the registry.
+func NewWorkspaceHandler(workspaces *workspace.Registry) *Handler {
+	jobManager := jobs.NewManager(workspaces, jobs.DefaultWorkers, jobs.DefaultQueueSize)
+	dispatcher := webhooks.NewDispatcher()
+	jobManager.OnFinish(dispatcher.Notify)
+	checker := health.NewChecker()
+	checker.Add("queue", jobManager.CheckQueue)
+	checker.Add("workers", jobManager.CheckWorkers)
+
 	return &Handler{
-		AnalyzerService: analyzerService,
+		Workspaces: workspaces,
+		Jobs:       jobManager,
+		Webhooks:   dispatcher,
+		Health:     checker,
 	}
 }
 
+// workspace returns the workspace of the request, as set by the authentication
+// middleware.
+func (h *Handler) workspace(c *gin.Context) string {
+	return workspace.FromContext(c.Request.Context())
+}
+
+// service returns the analyzer service of the request's workspace.
+func (h *Handler) service(c *gin.Context) analyzer.AnalyzerService {
+	return h.Workspaces.Service(h.workspace(c))
+}
+
 // AnalyzeHandler handles the HTTP request for analyzing a URL.
 // It validates the request, checks the URL format, and submits the URL for analysis.
 func (h *Handler) AnalyzeHandler(c *gin.Context) {
 	var req AnalyzeRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		slog.Error("Invalid request body", "error", err)
+		logger(c).Error("Invalid request body", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 	url := req.URL
 
-	if url == "" {
-		slog.Warn("URL parameter is missing")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "URL parameter is required"})
+	if msg := ValidateURL(url); msg != "" {
+		logger(c).Warn("Rejected URL", "url", url, "reason", msg)
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
 		return
 	}
-	if !isValidURL(url) {
-		slog.Warn("Invalid URL format", "url", url)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid URL"})
+
+	if req.CallbackURL != "" {
+		if msg := h.ValidateCallbackURL(req.CallbackURL); msg != "" {
+			logger(c).Warn("Rejected callback URL", "callback_url", req.CallbackURL, "reason", msg)
+			c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+	}
+
+	logger(c).Info("URL submitted for analysis", "url", url)
+	job, err := h.Jobs.SubmitWithCallback(c.Request.Context(), url, req.CallbackURL)
+	if err != nil {
+		logger(c).Error("Failed to queue analysis", "url", url, "error", err)
+		status, msg := submitError(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "URL submitted for analysis", "job_id": job.ID})
+}
+
+// JobHandler handles the HTTP request for retrieving the state of an analysis job.
+func (h *Handler) JobHandler(c *gin.Context) {
+	job, ok := h.Jobs.Job(c.Param("id"))
+	if !ok || job.Workspace != h.workspace(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
+	c.JSON(http.StatusOK, job)
+}
+
+// submitError maps an error from queueing a job to a status code and message.
+func submitError(err error) (int, string) {
+	if errors.Is(err, workspace.ErrQuotaExceeded) {
+		return http.StatusTooManyRequests, "Daily analysis quota exceeded"
+	}
+	return http.StatusServiceUnavailable, "Analysis queue is full"
+}
 
-	slog.Info("URL submitted for analysis", "url", url)
-	go h.AnalyzerService.AnalyzePage(url)
+// ValidateURL checks that url is present and well formed. It returns the error
+// message to report to the client, or an empty string when the URL is valid.
+func ValidateURL(url string) string {
+	if url == "" {
+		return "URL parameter is required"
+	}
+	if !isValidURL(url) {
+		return "Invalid URL"
+	}
+	return ""
+}
 
-	c.JSON(http.StatusAccepted, gin.H{"message": "URL submitted for analysis"})
+// ValidateCallbackURL checks a callback URL like ValidateURL. Callbacks are
+// rejected when no callback secret is configured, since receivers could not
+// tell them from forged requests.
+func (h *Handler) ValidateCallbackURL(url string) string {
+	if msg := ValidateURL(url); msg != "" {
+		return "Invalid callback URL"
+	}
+	if h.Webhooks == nil || h.Webhooks.CallbackSecret == "" {
+		return "Callback URLs are disabled: no webhook secret is configured"
+	}
+	return ""
 }
 
 func isValidURL(url string) bool {
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"web-analyzer/internal/analyzer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxHTMLSize is the largest HTML document accepted by AnalyzeHTMLHandler.
+const maxHTMLSize = 10 << 20
+
+// maxDocumentChecks is the number of links and resources checked per uploaded
+// document, so a large document cannot hold its request open for long.
+const maxDocumentChecks = 50
+
+// AnalyzeHTMLHandler handles the HTTP request for analysing raw HTML instead of
+// a live URL. The document is either the request body or a file uploaded as the
+// "file" form field. Relative links resolve against the optional base_url query
+// or form parameter and are not checked without it; at most maxDocumentChecks
+// links and resources are checked. The analysis runs synchronously and its
+// result is returned without being stored. Documents that cannot be analysed
+// do not count against the quota.
+func (h *Handler) AnalyzeHTMLHandler(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxHTMLSize)
+
+	baseURL := c.Query("base_url")
+	var doc io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Document is too large"})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": "file field is required"})
+			return
+		}
+		f, err := header.Open()
+		if err != nil {
+			logger(c).Error("Failed to open upload", "error", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unable to read upload"})
+			return
+		}
+		defer f.Close()
+		doc = f
+		if base := c.PostForm("base_url"); base != "" {
+			baseURL = base
+		}
+	}
+	if baseURL != "" {
+		if msg := ValidateURL(baseURL); msg != "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid base URL"})
+			return
+		}
+	}
+
+	workspaceID := h.workspace(c)
+	if err := h.Workspaces.ReserveAnalysis(workspaceID); err != nil {
+		status, msg := submitError(err)
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	ctx := analyzer.WithCheckLimit(c.Request.Context(), maxDocumentChecks)
+	result, err := h.service(c).AnalyzeDocument(ctx, doc, baseURL)
+	if err != nil {
+		h.Workspaces.ReleaseAnalysis(workspaceID)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Document is too large"})
+			return
+		}
+		logger(c).Error("Failed to analyse document", "base_url", baseURL, "error", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Unable to analyse document"})
+		return
+	}
+	logger(c).Info("Document analysed", "base_url", baseURL, "score", result.Score)
+	c.JSON(http.StatusOK, result)
+}
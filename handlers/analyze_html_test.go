@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"web-analyzer/handlers"
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func htmlRouter(h *handlers.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/analyze/html", h.AnalyzeHTMLHandler)
+	return router
+}
+
+func TestAnalyzeHTMLHandler_Body(t *testing.T) {
+	router := htmlRouter(handlers.NewHandler(&mockAnalyzerService{}))
+
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/html?base_url=https://example.com", strings.NewReader("<title>Raw</title>"))
+	req.Header.Set("Content-Type", "text/html")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var result models.AnalysisResult
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	assert.Equal(t, "https://example.com", result.URL)
+	assert.Equal(t, "<title>Raw</title>", result.Title)
+}
+
+func TestAnalyzeHTMLHandler_Upload(t *testing.T) {
+	router := htmlRouter(handlers.NewHandler(&mockAnalyzerService{}))
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "index.html")
+	part.Write([]byte("<title>Uploaded</title>"))
+	form.WriteField("base_url", "https://example.com/docs/")
+	form.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/html", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "https://example.com/docs/")
+	assert.Contains(t, resp.Body.String(), "Uploaded")
+}
+
+func TestAnalyzeHTMLHandler_Rejects(t *testing.T) {
+	h := handlers.NewWorkspaceHandler(workspace.NewRegistry(func(string) analyzer.AnalyzerService {
+		return &mockAnalyzerService{}
+	}, workspace.Quota{AnalysesPerDay: 1}))
+	router := htmlRouter(h)
+	post := func(query, body string) int {
+		req, _ := http.NewRequest(http.MethodPost, "/analyze/html"+query, strings.NewReader(body))
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post("?base_url=not-a-url", "<p>"))
+	// Oversized documents do not use up the quota.
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("", strings.Repeat("a", 11<<20)))
+	assert.Equal(t, http.StatusOK, post("", "<p>"))
+	assert.Equal(t, http.StatusTooManyRequests, post("", "<p>"))
+}
@@ -2,11 +2,14 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"web-analyzer/handlers"
+	"web-analyzer/internal/analyzer"
 	"web-analyzer/models"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +19,11 @@ import (
 // MockAnalyzerService implements analyzer.AnalyzerService
 type mockAnalyzerService struct{}
 
-func (m *mockAnalyzerService) AnalyzePage(url string) {
+func (m *mockAnalyzerService) AnalyzePage(ctx context.Context, url string) error {
 	// Mock implementation of AnalyzePage
+	result, _ := m.GetAnalysis(url)
+	analyzer.ReportResult(ctx, result, nil)
+	return nil
 }
 
 func (m *mockAnalyzerService) GetAnalysis(url string) (models.AnalysisResult, bool) {
@@ -29,6 +35,30 @@ func (m *mockAnalyzerService) GetAnalysis(url string) (models.AnalysisResult, bo
 	}, true
 }
 
+func (m *mockAnalyzerService) ListAnalyses() []models.AnalysisResult {
+	return nil
+}
+
+func (m *mockAnalyzerService) GetHistory(url string) []models.Snapshot {
+	return nil
+}
+
+func (m *mockAnalyzerService) GetSubmittedUrls() []string {
+	return nil
+}
+
+func (m *mockAnalyzerService) ListUrls() []models.URLEntry {
+	return nil
+}
+
+func (m *mockAnalyzerService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return models.AnalysisResult{}, err
+	}
+	return models.AnalysisResult{URL: baseURL, Status: "Completed", Title: string(body)}, nil
+}
+
 func TestAnalyzeHandler_ValidURL(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchSize is the maximum number of URLs accepted in a single batch.
+const maxBatchSize = 1000
+
+// maxBatchBytes is the largest batch request body accepted, enough for
+// maxBatchSize long URLs.
+const maxBatchBytes = 4 << 20
+
+// BatchItemError describes a batch entry that was rejected.
+type BatchItemError struct {
+	Index int    `json:"index"`
+	URL   string `json:"url"`
+	Error string `json:"error"`
+}
+
+// BatchJob links a batch entry to the job created for it.
+type BatchJob struct {
+	Index int    `json:"index"`
+	URL   string `json:"url"`
+	JobID string `json:"job_id"`
+}
+
+// BatchResponse is returned when a batch is accepted.
+type BatchResponse struct {
+	BatchID string           `json:"batch_id"`
+	Jobs    []BatchJob       `json:"jobs"`
+	Errors  []BatchItemError `json:"errors,omitempty"`
+}
+
+// AnalyzeBatchHandler handles the HTTP request for analyzing many URLs at once.
+// It accepts a JSON array of URLs (strings or {"url": ...} objects), or a CSV or
+// JSONL file uploaded as the "file" form field. Every URL goes through the same
+// validation as AnalyzeHandler; invalid entries are reported per item.
+func (h *Handler) AnalyzeBatchHandler(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBytes)
+
+	urls, err := readBatchURLs(c)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Batch exceeds %d bytes", maxBatchBytes)})
+		return
+	}
+	if err != nil {
+		logger(c).Error("Invalid batch request", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(urls) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Batch contains no URLs"})
+		return
+	}
+	if len(urls) > maxBatchSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("Batch exceeds %d URLs", maxBatchSize)})
+		return
+	}
+
+	resp := BatchResponse{Jobs: []BatchJob{}}
+	var valid []int
+	for i, url := range urls {
+		if msg := ValidateURL(url); msg != "" {
+			resp.Errors = append(resp.Errors, BatchItemError{Index: i, URL: url, Error: msg})
+			continue
+		}
+		valid = append(valid, i)
+	}
+	if len(valid) == 0 {
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	batch := h.Jobs.NewBatch(c.Request.Context())
+	resp.BatchID = batch.ID
+	for _, i := range valid {
+		job, err := h.Jobs.SubmitToBatch(c.Request.Context(), batch.ID, urls[i])
+		if err != nil {
+			_, msg := submitError(err)
+			resp.Errors = append(resp.Errors, BatchItemError{Index: i, URL: urls[i], Error: msg})
+			continue
+		}
+		resp.Jobs = append(resp.Jobs, BatchJob{Index: i, URL: urls[i], JobID: job.ID})
+	}
+
+	logger(c).Info("Batch submitted for analysis", "batch_id", batch.ID, "accepted", len(resp.Jobs), "rejected", len(resp.Errors))
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// BatchHandler handles the HTTP request for retrieving the aggregate progress of
+// a batch.
+func (h *Handler) BatchHandler(c *gin.Context) {
+	progress, ok := h.Jobs.BatchProgress(c.Param("id"))
+	if !ok || progress.Workspace != h.workspace(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Batch not found"})
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+// readBatchURLs extracts the submitted URLs from either a JSON body or an
+// uploaded file.
+func readBatchURLs(c *gin.Context) ([]string, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		header, err := c.FormFile("file")
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, errors.New("file field is required")
+		}
+		f, err := header.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read upload: %w", err)
+		}
+		defer f.Close()
+
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".csv":
+			return parseCSVURLs(f)
+		case ".jsonl", ".ndjson":
+			return parseJSONLURLs(f)
+		default:
+			return nil, errors.New("unsupported file type, expected .csv or .jsonl")
+		}
+	}
+
+	var items []json.RawMessage
+	if err := c.ShouldBindJSON(&items); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		return nil, errors.New("request body must be a JSON array of URLs")
+	}
+	urls := make([]string, 0, len(items))
+	for _, item := range items {
+		urls = append(urls, decodeURLItem(item))
+	}
+	return urls, nil
+}
+
+// decodeURLItem accepts either a JSON string or an AnalyzeRequest object. Items
+// that are neither yield an empty URL, which fails validation.
+func decodeURLItem(item json.RawMessage) string {
+	var url string
+	if err := json.Unmarshal(item, &url); err == nil {
+		return strings.TrimSpace(url)
+	}
+	var req AnalyzeRequest
+	if err := json.Unmarshal(item, &req); err == nil {
+		return strings.TrimSpace(req.URL)
+	}
+	return ""
+}
+
+// parseCSVURLs reads URLs from the first column of a CSV file. A header row whose
+// first cell is "url" is skipped.
+func parseCSVURLs(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var urls []string
+	for line := 0; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(record) == 0 {
+			continue
+		}
+		cell := strings.TrimSpace(record[0])
+		if line == 0 && strings.EqualFold(cell, "url") {
+			continue
+		}
+		urls = append(urls, cell)
+	}
+	return urls, nil
+}
+
+// parseJSONLURLs reads one URL per line, each line being a JSON string or an
+// AnalyzeRequest object. Blank lines are ignored.
+func parseJSONLURLs(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		urls = append(urls, decodeURLItem(json.RawMessage(line)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid JSONL: %w", err)
+	}
+	return urls, nil
+}
@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"web-analyzer/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupBatchRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.POST("/analyze/batch", h.AnalyzeBatchHandler)
+	router.GET("/batches/:id", h.BatchHandler)
+	return router
+}
+
+func TestAnalyzeBatchHandler_JSONArray(t *testing.T) {
+	router := setupBatchRouter()
+
+	body := []byte(`["https://example.com", {"url": "https://example.org"}, "invalid-url"]`)
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/batch", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var batch handlers.BatchResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &batch))
+	assert.NotEmpty(t, batch.BatchID)
+	assert.Len(t, batch.Jobs, 2)
+	assert.Len(t, batch.Errors, 1)
+	assert.Equal(t, 2, batch.Errors[0].Index)
+	assert.Equal(t, "Invalid URL", batch.Errors[0].Error)
+
+	req, _ = http.NewRequest(http.MethodGet, "/batches/"+batch.BatchID, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"total":2`)
+}
+
+func TestAnalyzeBatchHandler_CSVUpload(t *testing.T) {
+	router := setupBatchRouter()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, _ := writer.CreateFormFile("file", "urls.csv")
+	part.Write([]byte("url,owner\nhttps://example.com,web\nhttps://example.org,docs\n"))
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/batch", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	var batch handlers.BatchResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &batch))
+	assert.Len(t, batch.Jobs, 2)
+	assert.Empty(t, batch.Errors)
+}
+
+func TestAnalyzeBatchHandler_AllInvalid(t *testing.T) {
+	router := setupBatchRouter()
+
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/batch", bytes.NewBufferString(`["", "nope"]`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "URL parameter is required")
+}
+
+func TestAnalyzeBatchHandler_BodyTooLarge(t *testing.T) {
+	router := setupBatchRouter()
+
+	body := `["https://example.com/` + strings.Repeat("a", 5<<20) + `"]`
+	req, _ := http.NewRequest(http.MethodPost, "/analyze/batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Batch exceeds")
+}
+
+func TestBatchHandler_NotFound(t *testing.T) {
+	router := setupBatchRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/batches/missing", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
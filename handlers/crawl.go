@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/workspace"
+)
+
+// MaxCrawlPages is the largest number of pages a single crawl may analyse.
+const MaxCrawlPages = 1000
+
+// Crawl crawls the site of url in the workspace of ctx, reporting every page
+// to onPage if it is set. The crawl must fit the crawl quota of the workspace,
+// which runs at most Workspaces.MaxCrawls crawls at once, and every page
+// counts against its daily analysis quota. Quota violations are reported as
+// workspace.ErrQuotaExceeded.
+func (h *Handler) Crawl(ctx context.Context, url string, opts crawler.Options, onPage func(crawler.Page)) ([]crawler.Page, error) {
+	workspaceID := workspace.FromContext(ctx)
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = crawler.DefaultMaxPages
+	}
+	if err := h.Workspaces.CheckCrawl(workspaceID, opts.MaxPages); err != nil {
+		return nil, err
+	}
+	done, err := h.Workspaces.StartCrawl(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	c := crawler.New(reservingService{
+		AnalyzerService: h.Workspaces.Service(workspaceID),
+		reserve:         func() error { return h.Workspaces.ReserveAnalysis(workspaceID) },
+	}, opts)
+	c.OnPage = onPage
+
+	logging.FromContext(ctx).Info("Crawl started", "url", url, "max_pages", opts.MaxPages)
+	return c.Crawl(ctx, url)
+}
+
+// reservingService reserves an analysis from the workspace quota before every
+// page it analyses.
+type reservingService struct {
+	analyzer.AnalyzerService
+	reserve func() error
+}
+
+func (s reservingService) AnalyzePage(ctx context.Context, url string) error {
+	if err := s.reserve(); err != nil {
+		return err
+	}
+	return s.AnalyzerService.AnalyzePage(ctx, url)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"web-analyzer/internal/jobs"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// keepAliveInterval is how often a comment is sent on idle event streams so
+// proxies do not close the connection.
+const keepAliveInterval = 15 * time.Second
+
+// JobEventsHandler handles the HTTP request for streaming the progress of a job
+// as Server-Sent Events. Events already published are replayed first; clients
+// reconnecting with a Last-Event-ID header only receive newer events. The
+// stream ends after the completed or failed event.
+func (h *Handler) JobEventsHandler(c *gin.Context) {
+	if job, ok := h.Jobs.Job(c.Param("id")); !ok || job.Workspace != h.workspace(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	sub, ok := h.Jobs.Subscribe(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	defer sub.Close()
+
+	lastID, _ := strconv.Atoi(c.GetHeader("Last-Event-ID"))
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, event := range sub.Past {
+		if event.Seq > lastID {
+			renderEvent(c, event)
+		}
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, open := <-sub.Events:
+			if !open {
+				return false
+			}
+			if event.Seq > lastID {
+				renderEvent(c, event)
+			}
+		case <-keepAlive.C:
+			// Comments are ignored by clients; sse.Event cannot encode them.
+			io.WriteString(w, ": keep-alive\n\n")
+		}
+		return true
+	})
+}
+
+func renderEvent(c *gin.Context, event jobs.Event) {
+	c.Render(-1, sse.Event{Id: strconv.Itoa(event.Seq), Event: string(event.Type), Data: event})
+}
@@ -0,0 +1,51 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"web-analyzer/handlers"
+	"web-analyzer/internal/jobs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobEventsHandler_StreamsUntilCompletion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.GET("/jobs/:id/events", h.JobEventsHandler)
+
+	job, err := h.Jobs.Submit(context.Background(), "https://example.com")
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		j, _ := h.Jobs.Job(job.ID)
+		return j.Status == jobs.StatusCompleted
+	}, 2*time.Second, 5*time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodGet, "/jobs/"+job.ID+"/events", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.True(t, strings.HasPrefix(resp.Header().Get("Content-Type"), "text/event-stream"))
+	assert.Contains(t, resp.Body.String(), "event:completed")
+	assert.Contains(t, resp.Body.String(), "Mock Title")
+}
+
+func TestJobEventsHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.GET("/jobs/:id/events", h.JobEventsHandler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/jobs/missing/events", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
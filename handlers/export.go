@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"web-analyzer/internal/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportHandler handles the HTTP request for exporting analyses.
+// The format query parameter selects csv, jsonl (default) or html. Results can be
+// narrowed with repeated url parameters and the from, to, status and host filters.
+func (h *Handler) ExportHandler(c *gin.Context) {
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := export.Filter{
+		URLs:   c.QueryArray("url"),
+		Status: c.Query("status"),
+		Host:   c.Query("host"),
+	}
+	if filter.From, err = parseTimeParam(c.Query("from"), false); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from parameter"})
+		return
+	}
+	if filter.To, err = parseTimeParam(c.Query("to"), true); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to parameter"})
+		return
+	}
+
+	results := filter.Apply(h.service(c).ListAnalyses())
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="analyses.%s"`, format))
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Writer, format, results); err != nil {
+		logger(c).Error("Failed to write export", "format", format, "error", err)
+	}
+}
+
+// parseTimeParam accepts an RFC 3339 timestamp or a plain date. A plain date used
+// as an upper bound covers the whole day.
+func parseTimeParam(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
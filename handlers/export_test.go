@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupExportRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	service := &MockAnalyzerService{
+		analysisData: map[string]models.AnalysisResult{
+			"https://example.com": {URL: "https://example.com", Status: "Completed", Title: "Example"},
+			"https://other.org":   {URL: "https://other.org", Status: "Failed"},
+		},
+	}
+	h := handlers.NewHandler(service)
+	router := gin.Default()
+	router.GET("/export", h.ExportHandler)
+	return router
+}
+
+func TestExportHandler_CSVWithHostFilter(t *testing.T) {
+	router := setupExportRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/export?format=csv&host=example.com", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "text/csv")
+	assert.Contains(t, resp.Body.String(), "https://example.com")
+	assert.NotContains(t, resp.Body.String(), "https://other.org")
+}
+
+func TestExportHandler_InvalidFormat(t *testing.T) {
+	router := setupExportRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/export?format=xml", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestExportHandler_InvalidDate(t *testing.T) {
+	router := setupExportRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/export?from=yesterday", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"web-analyzer/internal/buildinfo"
+	"web-analyzer/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthzHandler handles the liveness probe. It succeeds as long as the server
+// is able to serve requests.
+func (h *Handler) HealthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// ReadyzHandler handles the readiness probe. It runs every registered check and
+// responds with 503 when any of them fails.
+func (h *Handler) ReadyzHandler(c *gin.Context) {
+	report := h.Health.Run(c.Request.Context())
+	if report.Status != health.StatusOK {
+		logger(c).Warn("Readiness check failed", "checks", report.Checks)
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// VersionHandler handles the HTTP request for the build information of the
+// running server.
+func (h *Handler) VersionHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
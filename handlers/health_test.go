@@ -0,0 +1,71 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/internal/buildinfo"
+	"web-analyzer/internal/health"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHealthRouter(h *handlers.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/healthz", h.HealthzHandler)
+	router.GET("/readyz", h.ReadyzHandler)
+	router.GET("/version", h.VersionHandler)
+	return router
+}
+
+func TestHealthzHandler(t *testing.T) {
+	router := setupHealthRouter(handlers.NewHandler(&mockAnalyzerService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, resp.Body.String())
+}
+
+func TestReadyzHandler(t *testing.T) {
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := setupHealthRouter(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var report health.Report
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+	assert.Equal(t, health.StatusOK, report.Checks["queue"].Status)
+	assert.Equal(t, health.StatusOK, report.Checks["workers"].Status)
+
+	h.Health.Add("storage", func(ctx context.Context) error { return errors.New("unreachable") })
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Contains(t, resp.Body.String(), "unreachable")
+}
+
+func TestVersionHandler(t *testing.T) {
+	router := setupHealthRouter(handlers.NewHandler(&mockAnalyzerService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/version", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	var info buildinfo.Info
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &info))
+	assert.Equal(t, buildinfo.Version, info.Version)
+	assert.NotEmpty(t, info.GoVersion)
+	assert.NotEmpty(t, info.Commit)
+}
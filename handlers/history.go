@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"web-analyzer/internal/analysis"
+	"web-analyzer/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HistoryHandler handles the HTTP request for listing the snapshots of a URL.
+// The URL is taken from the path and must be percent-encoded.
+func (h *Handler) HistoryHandler(c *gin.Context) {
+	url := c.Param("url")
+	history := h.service(c).GetHistory(url)
+	if len(history) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No history found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"url": url, "snapshots": history})
+}
+
+// DiffHandler handles the HTTP request for comparing two snapshots of a URL.
+// The from and to query parameters select snapshot versions; they default to
+// the previous and the latest snapshot.
+func (h *Handler) DiffHandler(c *gin.Context) {
+	url := c.Param("url")
+	history := h.service(c).GetHistory(url)
+	if len(history) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No history found"})
+		return
+	}
+
+	to, ok := snapshotVersion(c, "to", latestVersion(history), history)
+	if !ok {
+		return
+	}
+	from, ok := snapshotVersion(c, "from", previousVersion(to, history), history)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis.Diff(from, to))
+}
+
+// snapshotVersion looks up the snapshot selected by the named query parameter,
+// writing an error response when it is invalid.
+func snapshotVersion(c *gin.Context, param string, def int, history []models.Snapshot) (models.Snapshot, bool) {
+	snapshot, status, msg := selectSnapshot(c, param, def, history)
+	if status != http.StatusOK {
+		c.JSON(status, gin.H{"error": msg})
+		return models.Snapshot{}, false
+	}
+	return snapshot, true
+}
+
+// selectSnapshot looks up the snapshot selected by the named query parameter,
+// defaulting to version def. When the version is invalid it returns the status
+// code and message to report.
+func selectSnapshot(c *gin.Context, param string, def int, history []models.Snapshot) (models.Snapshot, int, string) {
+	version := def
+	if v := c.Query(param); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return models.Snapshot{}, http.StatusBadRequest, "Invalid " + param + " version"
+		}
+		version = n
+	}
+	for _, snapshot := range history {
+		if snapshot.Version == version {
+			return snapshot, http.StatusOK, ""
+		}
+	}
+	return models.Snapshot{}, http.StatusNotFound, "Snapshot not found"
+}
+
+// latestVersion returns the version of the newest snapshot of history.
+func latestVersion(history []models.Snapshot) int {
+	return history[len(history)-1].Version
+}
+
+// previousVersion returns the version before to, or the oldest retained one.
+func previousVersion(to models.Snapshot, history []models.Snapshot) int {
+	return max(to.Version-1, history[0].Version)
+}
@@ -0,0 +1,74 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHistoryRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	service := &MockAnalyzerService{
+		history: map[string][]models.Snapshot{
+			"https://example.com/docs": {
+				{Version: 1, Result: models.AnalysisResult{URL: "https://example.com/docs", Status: "Completed", Title: "Old"}},
+				{Version: 2, Result: models.AnalysisResult{URL: "https://example.com/docs", Status: "Completed", Title: "New"}},
+			},
+		},
+	}
+	h := handlers.NewHandler(service)
+	router := gin.Default()
+	router.UseRawPath = true
+	router.UnescapePathValues = true
+	router.GET("/urls/:url/history", h.HistoryHandler)
+	router.GET("/urls/:url/diff", h.DiffHandler)
+	return router
+}
+
+func TestHistoryHandler(t *testing.T) {
+	router := setupHistoryRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls/"+url.PathEscape("https://example.com/docs")+"/history", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"Version":2`)
+}
+
+func TestHistoryHandler_NotFound(t *testing.T) {
+	router := setupHistoryRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls/"+url.PathEscape("https://unknown.com")+"/history", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestDiffHandler_DefaultsToLatest(t *testing.T) {
+	router := setupHistoryRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls/"+url.PathEscape("https://example.com/docs")+"/diff", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"Field":"Title","Old":"Old","New":"New"`)
+}
+
+func TestDiffHandler_UnknownVersion(t *testing.T) {
+	router := setupHistoryRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls/"+url.PathEscape("https://example.com/docs")+"/diff?from=1&to=5", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyRequest represents the request body for issuing an API key.
+type KeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// Workspace defaults to the workspace of the caller's key. Only superadmin
+	// keys may issue keys into other workspaces.
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// KeyResponse is returned when a key is issued. It is the only response that
+// includes the key itself.
+type KeyResponse struct {
+	auth.Key
+	Secret string `json:"key"`
+}
+
+// CreateKeyHandler handles the HTTP request for issuing an API key.
+func (h *Handler) CreateKeyHandler(c *gin.Context) {
+	caller, ok := h.keyAdmin(c)
+	if !ok {
+		return
+	}
+	var req KeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Name is required"})
+		return
+	}
+	if len(req.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one scope is required"})
+		return
+	}
+	if req.Workspace == "" {
+		req.Workspace = caller.Workspace
+	}
+	if err := workspace.ValidateID(req.Workspace); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace"})
+		return
+	}
+	if !caller.Manages(req.Workspace) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key cannot manage workspace " + req.Workspace})
+		return
+	}
+	scopes := make([]auth.Scope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scope, err := auth.ParseScope(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown scope " + s})
+			return
+		}
+		if !caller.Allows(scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API key cannot grant the " + s + " scope"})
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+
+	key, secret, err := h.Auth.Issue(req.Name, req.Workspace, scopes)
+	if err != nil {
+		logger(c).Error("Failed to issue API key", "name", req.Name, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue API key"})
+		return
+	}
+	logger(c).Info("API key issued", "issued_key_id", key.ID, "name", key.Name, "workspace", key.Workspace, "scopes", key.Scopes)
+	c.JSON(http.StatusCreated, KeyResponse{Key: key, Secret: secret})
+}
+
+// KeysHandler handles the HTTP request for listing the API keys the caller
+// manages.
+func (h *Handler) KeysHandler(c *gin.Context) {
+	caller, ok := h.keyAdmin(c)
+	if !ok {
+		return
+	}
+	keys := []auth.Key{}
+	for _, key := range h.Auth.List() {
+		if caller.Manages(key.Workspace) {
+			keys = append(keys, key)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+// RevokeKeyHandler handles the HTTP request for revoking an API key. Keys of
+// workspaces the caller does not manage are reported as not found.
+func (h *Handler) RevokeKeyHandler(c *gin.Context) {
+	caller, ok := h.keyAdmin(c)
+	if !ok {
+		return
+	}
+	key, err := h.Auth.Get(c.Param("id"))
+	if err == nil && !caller.Manages(key.Workspace) {
+		err = auth.ErrNotFound
+	}
+	if err == nil {
+		key, err = h.Auth.Revoke(key.ID)
+	}
+	if err != nil {
+		if errors.Is(err, auth.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+			return
+		}
+		logger(c).Error("Failed to revoke API key", "revoked_key_id", c.Param("id"), "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		return
+	}
+	logger(c).Info("API key revoked", "revoked_key_id", key.ID)
+	c.JSON(http.StatusOK, key)
+}
+
+// keyAdmin returns the key of a request allowed to manage keys.
+func (h *Handler) keyAdmin(c *gin.Context) (auth.Key, bool) {
+	if h.Auth == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Authentication is not enabled"})
+		return auth.Key{}, false
+	}
+	caller, ok := auth.KeyFromContext(c)
+	if !ok || !caller.Allows(auth.ScopeAdmin) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key lacks the admin scope"})
+		return auth.Key{}, false
+	}
+	return caller, true
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupKeysRouter(h *handlers.Handler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	if h.Auth != nil {
+		router.Use(auth.Middleware(h.Auth, auth.ScopeAdmin))
+	}
+	router.POST("/admin/keys", h.CreateKeyHandler)
+	router.GET("/admin/keys", h.KeysHandler)
+	router.DELETE("/admin/keys/:id", h.RevokeKeyHandler)
+	return router
+}
+
+// keysRequest sends an admin request authenticated with secret.
+func keysRequest(router *gin.Engine, secret, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(auth.APIKeyHeader, secret)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestKeyHandlers_IssueListRevoke(t *testing.T) {
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	h.Auth, _ = auth.NewStore("")
+	_, admin, _ := h.Auth.Issue("admin", "team-a", []auth.Scope{auth.ScopeAdmin})
+	router := setupKeysRouter(h)
+
+	resp := keysRequest(router, admin, http.MethodPost, "/admin/keys", `{"name": "ci", "scopes": ["analyze", "read"]}`)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var created handlers.KeyResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Secret)
+	assert.Equal(t, []auth.Scope{auth.ScopeAnalyze, auth.ScopeRead}, created.Scopes)
+	// Keys are issued into the workspace of the caller by default.
+	assert.Equal(t, "team-a", created.Workspace)
+
+	resp = keysRequest(router, admin, http.MethodGet, "/admin/keys", "")
+	assert.Contains(t, resp.Body.String(), created.ID)
+	assert.NotContains(t, resp.Body.String(), created.Secret)
+	assert.NotContains(t, resp.Body.String(), `"hash"`)
+
+	resp = keysRequest(router, admin, http.MethodDelete, "/admin/keys/"+created.ID, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "revoked_at")
+
+	_, ok := h.Auth.Authenticate(created.Secret)
+	assert.False(t, ok)
+}
+
+func TestCreateKeyHandler_UnknownScope(t *testing.T) {
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	h.Auth, _ = auth.NewStore("")
+	_, admin, _ := h.Auth.Issue("admin", workspace.Default, []auth.Scope{auth.ScopeAdmin})
+	router := setupKeysRouter(h)
+
+	resp := keysRequest(router, admin, http.MethodPost, "/admin/keys", `{"name": "ci", "scopes": ["root"]}`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestKeyHandlers_WorkspaceIsolation(t *testing.T) {
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	h.Auth, _ = auth.NewStore("")
+	_, adminA, _ := h.Auth.Issue("admin-a", "team-a", []auth.Scope{auth.ScopeAdmin})
+	keyB, _, _ := h.Auth.Issue("ci-b", "team-b", []auth.Scope{auth.ScopeRead})
+	_, super, _ := h.Auth.Issue("root", workspace.Default, []auth.Scope{auth.ScopeSuperAdmin})
+	router := setupKeysRouter(h)
+
+	// A workspace admin cannot mint keys for another workspace.
+	resp := keysRequest(router, adminA, http.MethodPost, "/admin/keys", `{"name": "x", "scopes": ["read"], "workspace": "team-b"}`)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	// Nor escalate to the superadmin scope.
+	resp = keysRequest(router, adminA, http.MethodPost, "/admin/keys", `{"name": "x", "scopes": ["superadmin"]}`)
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+
+	resp = keysRequest(router, adminA, http.MethodGet, "/admin/keys", "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotContains(t, resp.Body.String(), keyB.ID)
+
+	resp = keysRequest(router, adminA, http.MethodDelete, "/admin/keys/"+keyB.ID, "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	got, _ := h.Auth.Get(keyB.ID)
+	assert.False(t, got.Revoked())
+
+	// A superadmin manages every workspace.
+	resp = keysRequest(router, super, http.MethodPost, "/admin/keys", `{"name": "x", "scopes": ["admin"], "workspace": "team-b"}`)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	resp = keysRequest(router, super, http.MethodDelete, "/admin/keys/"+keyB.ID, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestKeyHandlers_AuthDisabled(t *testing.T) {
+	router := setupKeysRouter(handlers.NewHandler(&mockAnalyzerService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/admin/keys", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"web-analyzer/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelRequest represents the request body for changing the log level.
+type LogLevelRequest struct {
+	Level string `json:"level"`
+}
+
+// LogLevelHandler handles the HTTP request for reading the current log level.
+func (h *Handler) LogLevelHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"level": logging.Level().String()})
+}
+
+// SetLogLevelHandler handles the HTTP request for changing the log level while
+// the server runs.
+func (h *Handler) SetLogLevelHandler(c *gin.Context) {
+	var req LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	previous := logging.Level()
+	logging.SetLevel(level)
+	logger(c).Warn("Log level changed", "from", previous.String(), "to", level.String())
+	c.JSON(http.StatusOK, gin.H{"level": level.String()})
+}
+
+// logger returns the request scoped logger, tagged with the request ID.
+func logger(c *gin.Context) *slog.Logger {
+	return logging.FromContext(c.Request.Context())
+}
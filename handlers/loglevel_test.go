@@ -0,0 +1,41 @@
+package handlers_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelHandlers(t *testing.T) {
+	defer logging.SetLevel(logging.Level())
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.GET("/log-level", h.LogLevelHandler)
+	router.PUT("/log-level", h.SetLogLevelHandler)
+
+	req, _ := http.NewRequest(http.MethodPut, "/log-level", bytes.NewBufferString(`{"level": "debug"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, slog.LevelDebug, logging.Level())
+
+	req, _ = http.NewRequest(http.MethodGet, "/log-level", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.JSONEq(t, `{"level": "DEBUG"}`, resp.Body.String())
+
+	req, _ = http.NewRequest(http.MethodPut, "/log-level", bytes.NewBufferString(`{"level": "loud"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
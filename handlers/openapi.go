@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/buildinfo"
+	"web-analyzer/internal/openapi"
+	"web-analyzer/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIHandler handles the HTTP request for the OpenAPI document of the /v1
+// API.
+func (h *Handler) OpenAPIHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, V1Spec(h.V1Routes(), h.Auth != nil))
+}
+
+// V1Spec generates the OpenAPI document of the /v1 routes. Authenticated
+// routes declare the API key schemes and their 401, 403 and 429 problems when
+// withAuth is set.
+func V1Spec(routes []Route, withAuth bool) *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Web Analyzer API",
+		Version:     buildinfo.Get().Version,
+		Description: "Analyses web pages for their HTML version, headings, links and login forms. Errors are RFC 7807 problem details.",
+	})
+	if withAuth {
+		doc.Components.SecuritySchemes["bearer"] = &openapi.SecurityScheme{Type: "http", Scheme: "bearer"}
+		doc.Components.SecuritySchemes["apiKey"] = &openapi.SecurityScheme{Type: "apiKey", In: "header", Name: auth.APIKeyHeader}
+	}
+	problemBody := map[string]openapi.MediaType{problem.ContentType: {Schema: doc.Schema(problem.Problem{})}}
+	failure := func(status int) *openapi.Response {
+		return &openapi.Response{Description: http.StatusText(status), Content: problemBody}
+	}
+
+	for _, route := range routes {
+		op := openapi.Operation{
+			OperationID: route.OperationID,
+			Summary:     route.Summary,
+			Tags:        []string{route.Tag},
+			Parameters:  append(pathParameters(route.Path), route.Query...),
+			Responses: map[string]*openapi.Response{
+				strconv.Itoa(route.Status): {
+					Description: http.StatusText(route.Status),
+					Content:     map[string]openapi.MediaType{"application/json": {Schema: doc.Schema(route.Response)}},
+				},
+			},
+		}
+		if route.Request != nil {
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content:  map[string]openapi.MediaType{"application/json": {Schema: doc.Schema(route.Request)}},
+			}
+		}
+		if route.Request != nil || len(route.Query) > 0 {
+			op.Responses["400"] = failure(http.StatusBadRequest)
+		}
+		if strings.Contains(route.Path, ":") {
+			op.Responses["404"] = failure(http.StatusNotFound)
+		}
+		if withAuth {
+			// Scopes can only be listed for OAuth schemes, so they are described.
+			op.Description = "Requires an API key with the " + string(route.Scope) + " scope."
+			op.Security = []openapi.SecurityRequirement{{"bearer": {}}, {"apiKey": {}}}
+			op.Responses["401"] = failure(http.StatusUnauthorized)
+			op.Responses["403"] = failure(http.StatusForbidden)
+			op.Responses["429"] = failure(http.StatusTooManyRequests)
+		}
+		if route.Status == http.StatusAccepted {
+			op.Responses["429"] = failure(http.StatusTooManyRequests)
+			op.Responses["503"] = failure(http.StatusServiceUnavailable)
+		}
+		doc.Add(route.Method, "/v1"+route.Path, op)
+	}
+	return doc
+}
+
+// pathParameters describes the ":param" segments of a route path.
+func pathParameters(path string) []openapi.Parameter {
+	var params []openapi.Parameter
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			param := openapi.Parameter{Name: name, In: "path", Required: true, Schema: &openapi.Schema{Type: "string"}}
+			if name == "url" {
+				param.Description = "The analysed URL, percent-encoded"
+			}
+			params = append(params, param)
+		}
+	}
+	return params
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/scheduler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduleRequest represents the request body for registering a schedule.
+type ScheduleRequest struct {
+	URL      string `json:"url"`
+	Schedule string `json:"schedule"`
+	// Kind is scheduler.KindAnalysis, the default, or scheduler.KindCrawl.
+	Kind string `json:"kind,omitempty"`
+	// MaxPages and MaxDepth bound the crawls of crawl schedules.
+	MaxPages int `json:"max_pages,omitempty"`
+	MaxDepth int `json:"max_depth,omitempty"`
+}
+
+// CreateScheduleHandler handles the HTTP request for registering a recurring
+// analysis of a URL or crawl of a site.
+func (h *Handler) CreateScheduleHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if msg := ValidateURL(req.URL); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if req.Schedule == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Schedule is required"})
+		return
+	}
+	if _, err := scheduler.ParseSpec(req.Schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule"})
+		return
+	}
+
+	var schedule scheduler.Schedule
+	var err error
+	switch req.Kind {
+	case "", scheduler.KindAnalysis:
+		schedule, err = h.Scheduler.Add(h.workspace(c), req.URL, req.Schedule)
+	case scheduler.KindCrawl:
+		opts := crawler.Options{MaxPages: req.MaxPages, MaxDepth: req.MaxDepth}
+		if opts.MaxPages < 0 || opts.MaxPages > MaxCrawlPages || opts.MaxDepth < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("max_pages must be between 0 and %d and max_depth at least 0", MaxCrawlPages)})
+			return
+		}
+		pages := opts.MaxPages
+		if pages == 0 {
+			pages = crawler.DefaultMaxPages
+		}
+		if err := h.Workspaces.CheckCrawl(h.workspace(c), pages); err != nil {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		schedule, err = h.Scheduler.AddCrawl(h.workspace(c), req.URL, req.Schedule, opts)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown schedule kind " + req.Kind})
+		return
+	}
+	if err != nil {
+		logger(c).Error("Failed to create schedule", "url", req.URL, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create schedule"})
+		return
+	}
+	logger(c).Info("Schedule created", "schedule_id", schedule.ID, "kind", schedule.Kind, "url", schedule.URL, "schedule", schedule.Spec)
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// SchedulesHandler handles the HTTP request for listing schedules.
+func (h *Handler) SchedulesHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"schedules": h.Scheduler.List(h.workspace(c))})
+}
+
+// ScheduleHandler handles the HTTP request for retrieving a schedule and its runs.
+func (h *Handler) ScheduleHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	schedule, ok := h.Scheduler.Get(h.workspace(c), c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// PauseScheduleHandler handles the HTTP request for pausing a schedule.
+func (h *Handler) PauseScheduleHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	h.respondSchedule(c, h.Scheduler.Pause)
+}
+
+// ResumeScheduleHandler handles the HTTP request for resuming a paused schedule.
+func (h *Handler) ResumeScheduleHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	h.respondSchedule(c, h.Scheduler.Resume)
+}
+
+// DeleteScheduleHandler handles the HTTP request for deleting a schedule.
+func (h *Handler) DeleteScheduleHandler(c *gin.Context) {
+	if !h.schedulingEnabled(c) {
+		return
+	}
+	if err := h.Scheduler.Delete(h.workspace(c), c.Param("id")); err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) respondSchedule(c *gin.Context, fn func(workspaceID, id string) (scheduler.Schedule, error)) {
+	schedule, err := fn(h.workspace(c), c.Param("id"))
+	if err != nil {
+		h.scheduleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) scheduleError(c *gin.Context, err error) {
+	if errors.Is(err, scheduler.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Schedule not found"})
+		return
+	}
+	logger(c).Error("Failed to update schedule", "schedule_id", c.Param("id"), "error", err)
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update schedule"})
+}
+
+func (h *Handler) schedulingEnabled(c *gin.Context) bool {
+	if h.Scheduler == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Scheduling is not enabled"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"web-analyzer/handlers"
+	"web-analyzer/internal/scheduler"
+	"web-analyzer/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupScheduleRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	sched, err := scheduler.New(filepath.Join(t.TempDir(), "schedules.json"), h.Jobs)
+	assert.NoError(t, err)
+	h.Scheduler = sched
+
+	router := gin.Default()
+	router.POST("/schedules", h.CreateScheduleHandler)
+	router.GET("/schedules/:id", h.ScheduleHandler)
+	router.POST("/schedules/:id/pause", h.PauseScheduleHandler)
+	router.DELETE("/schedules/:id", h.DeleteScheduleHandler)
+	return router
+}
+
+func TestScheduleHandlers_Lifecycle(t *testing.T) {
+	router := setupScheduleRouter(t)
+
+	body, _ := json.Marshal(handlers.ScheduleRequest{URL: "https://example.com", Schedule: "@daily"})
+	req, _ := http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var created scheduler.Schedule
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.ID)
+
+	req, _ = http.NewRequest(http.MethodPost, "/schedules/"+created.ID+"/pause", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"paused":true`)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/schedules/"+created.ID, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/schedules/"+created.ID, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestCreateScheduleHandler_InvalidSchedule(t *testing.T) {
+	router := setupScheduleRouter(t)
+
+	body, _ := json.Marshal(handlers.ScheduleRequest{URL: "https://example.com", Schedule: "sometimes"})
+	req, _ := http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Invalid schedule")
+}
+
+func TestCreateScheduleHandler_Crawl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	assert.NoError(t, h.Workspaces.SetQuota(workspace.Default, workspace.Quota{CrawlPages: 20}))
+	sched, err := scheduler.New(filepath.Join(t.TempDir(), "schedules.json"), h.Jobs)
+	assert.NoError(t, err)
+	h.Scheduler = sched
+	router := gin.Default()
+	router.POST("/schedules", h.CreateScheduleHandler)
+
+	tests := []struct {
+		name string
+		req  handlers.ScheduleRequest
+		want int
+	}{
+		{"within quota", handlers.ScheduleRequest{Kind: scheduler.KindCrawl, MaxPages: 20, MaxDepth: 2}, http.StatusCreated},
+		{"over quota", handlers.ScheduleRequest{Kind: scheduler.KindCrawl, MaxPages: 21}, http.StatusTooManyRequests},
+		// The default of 50 pages exceeds the quota too.
+		{"default pages over quota", handlers.ScheduleRequest{Kind: scheduler.KindCrawl}, http.StatusTooManyRequests},
+		{"negative depth", handlers.ScheduleRequest{Kind: scheduler.KindCrawl, MaxPages: 10, MaxDepth: -1}, http.StatusBadRequest},
+		{"unknown kind", handlers.ScheduleRequest{Kind: "sitemap"}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.URL = "https://example.com"
+			tt.req.Schedule = "@daily"
+			body, _ := json.Marshal(tt.req)
+			req, _ := http.NewRequest(http.MethodPost, "/schedules", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+			assert.Equal(t, tt.want, resp.Code)
+		})
+	}
+
+	schedules := sched.List(workspace.Default)
+	if assert.Len(t, schedules, 1) {
+		assert.Equal(t, scheduler.KindCrawl, schedules[0].Kind)
+		assert.Equal(t, 20, schedules[0].MaxPages)
+	}
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"log/slog"
 	"net/http"
 
 	"web-analyzer/internal/analyzer"
@@ -18,14 +17,14 @@ type DefaultAnalyzerService struct {
 func (h *Handler) StatusHandler(c *gin.Context) {
 	url := c.Query("url")
 	if url == "" {
-		slog.Warn("Missing URL parameter in status check")
+		logger(c).Warn("Missing URL parameter in status check")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "URL parameter is required"})
 		return
 	}
 
-	analysis, exists := h.AnalyzerService.GetAnalysis(url)
+	analysis, exists := h.service(c).GetAnalysis(url)
 	if !exists {
-		slog.Info("Analysis not found", "url", url)
+		logger(c).Info("Analysis not found", "url", url)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Analysis not found"})
 		return
 	}
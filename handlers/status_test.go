@@ -1,6 +1,8 @@
 package handlers_test
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,14 +17,39 @@ import (
 // MockAnalyzerService implements the AnalyzerService interface for testing.
 type MockAnalyzerService struct {
 	analysisData map[string]models.AnalysisResult
+	history      map[string][]models.Snapshot
 }
 
-func (m *MockAnalyzerService) AnalyzePage(url string) {}
+func (m *MockAnalyzerService) AnalyzePage(ctx context.Context, url string) error { return nil }
 func (m *MockAnalyzerService) GetAnalysis(url string) (models.AnalysisResult, bool) {
 	result, ok := m.analysisData[url]
 	return result, ok
 }
 
+func (m *MockAnalyzerService) ListAnalyses() []models.AnalysisResult {
+	results := make([]models.AnalysisResult, 0, len(m.analysisData))
+	for _, result := range m.analysisData {
+		results = append(results, result)
+	}
+	return results
+}
+
+func (m *MockAnalyzerService) GetHistory(url string) []models.Snapshot {
+	return m.history[url]
+}
+
+func (m *MockAnalyzerService) GetSubmittedUrls() []string {
+	return nil
+}
+
+func (m *MockAnalyzerService) ListUrls() []models.URLEntry {
+	return nil
+}
+
+func (m *MockAnalyzerService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	return models.AnalysisResult{}, nil
+}
+
 func setupRouter(service analyzer.AnalyzerService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"web-analyzer/internal/analysis"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/export"
+
+	"github.com/gin-gonic/gin"
+)
+
+// uiJobsLimit is the number of recent jobs listed on the dashboard.
+const uiJobsLimit = 50
+
+// uiLink is a link of the result page.
+type uiLink struct {
+	URL    string
+	Broken bool
+}
+
+// uiHeading is the number of headings of a level on the result page.
+type uiHeading struct {
+	Level string
+	Count int
+}
+
+// render renders a dashboard page. data is extended with the fields used by
+// the layout.
+func (h *Handler) render(c *gin.Context, status int, name, title string, data gin.H) {
+	if data == nil {
+		data = gin.H{}
+	}
+	data["Title"] = title
+	data["Workspace"] = h.workspace(c)
+	_, data["SignedIn"] = auth.KeyFromContext(c)
+	c.HTML(status, name, data)
+}
+
+// renderError renders the error page with the given status code.
+func (h *Handler) renderError(c *gin.Context, status int, msg string) {
+	h.render(c, status, "error.html", http.StatusText(status), gin.H{"Errors": []string{msg}})
+}
+
+// UIHandler handles the HTTP request for the dashboard, where URLs are
+// submitted and recent jobs listed.
+func (h *Handler) UIHandler(c *gin.Context) {
+	h.render(c, http.StatusOK, "index.html", "Dashboard", gin.H{
+		"Jobs": h.Jobs.List(h.workspace(c), uiJobsLimit),
+	})
+}
+
+// UIJobsHandler handles the HTTP request for the rows of the dashboard's job
+// table, which the page polls to show live progress.
+func (h *Handler) UIJobsHandler(c *gin.Context) {
+	c.HTML(http.StatusOK, "jobs.html", gin.H{"Jobs": h.Jobs.List(h.workspace(c), uiJobsLimit)})
+}
+
+// UISubmitHandler handles the dashboard form submitting URLs for analysis, one
+// per line. Nothing is queued unless every URL is valid.
+func (h *Handler) UISubmitHandler(c *gin.Context) {
+	input := c.PostForm("urls")
+	var urls, errs []string
+	for _, line := range strings.Split(input, "\n") {
+		url := strings.TrimSpace(line)
+		if url == "" {
+			continue
+		}
+		if msg := ValidateURL(url); msg != "" {
+			errs = append(errs, url+": "+msg)
+			continue
+		}
+		urls = append(urls, url)
+	}
+	if len(urls) == 0 && len(errs) == 0 {
+		errs = append(errs, ValidateURL(""))
+	}
+
+	for _, url := range urls {
+		if len(errs) > 0 {
+			break
+		}
+		logger(c).Info("URL submitted for analysis", "url", url)
+		if _, err := h.Jobs.Submit(c.Request.Context(), url); err != nil {
+			logger(c).Error("Failed to queue analysis", "url", url, "error", err)
+			_, msg := submitError(err)
+			errs = append(errs, url+": "+msg)
+		}
+	}
+
+	if len(errs) > 0 {
+		h.render(c, http.StatusBadRequest, "index.html", "Dashboard", gin.H{
+			"Errors": errs,
+			"URLs":   input,
+			"Jobs":   h.Jobs.List(h.workspace(c), uiJobsLimit),
+		})
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/ui/")
+}
+
+// UIResultHandler handles the HTTP request for the result page of the URL in
+// the url query parameter.
+func (h *Handler) UIResultHandler(c *gin.Context) {
+	url := c.Query("url")
+	result, ok := h.service(c).GetAnalysis(url)
+	if !ok {
+		h.renderError(c, http.StatusNotFound, "No analysis found for this URL")
+		return
+	}
+
+	broken := make(map[string]bool, len(result.BrokenLinkURLs))
+	for _, link := range result.BrokenLinkURLs {
+		broken[link] = true
+	}
+	links := make([]uiLink, 0, len(result.Links))
+	for _, link := range result.Links {
+		links = append(links, uiLink{URL: link, Broken: broken[link]})
+	}
+	headings := make([]uiHeading, 0, len(result.Headings))
+	for level, count := range result.Headings {
+		headings = append(headings, uiHeading{Level: level, Count: count})
+	}
+	sort.Slice(headings, func(i, j int) bool { return headings[i].Level < headings[j].Level })
+
+	h.render(c, http.StatusOK, "result.html", "Result", gin.H{
+		"Result":   result,
+		"Findings": export.Findings(result),
+		"Headings": headings,
+		"Links":    links,
+	})
+}
+
+// UIHistoryHandler handles the HTTP request for the snapshots of the URL in
+// the url query parameter.
+func (h *Handler) UIHistoryHandler(c *gin.Context) {
+	url := c.Query("url")
+	history := h.service(c).GetHistory(url)
+	if len(history) == 0 {
+		h.renderError(c, http.StatusNotFound, "No history found for this URL")
+		return
+	}
+	h.render(c, http.StatusOK, "history.html", "History", gin.H{"URL": url, "Snapshots": history})
+}
+
+// UIDiffHandler handles the HTTP request for comparing two snapshots of the
+// URL in the url query parameter, selected like DiffHandler does.
+func (h *Handler) UIDiffHandler(c *gin.Context) {
+	url := c.Query("url")
+	history := h.service(c).GetHistory(url)
+	if len(history) == 0 {
+		h.renderError(c, http.StatusNotFound, "No history found for this URL")
+		return
+	}
+
+	to, status, msg := selectSnapshot(c, "to", latestVersion(history), history)
+	if status != http.StatusOK {
+		h.renderError(c, status, msg)
+		return
+	}
+	from, status, msg := selectSnapshot(c, "from", previousVersion(to, history), history)
+	if status != http.StatusOK {
+		h.renderError(c, status, msg)
+		return
+	}
+	h.render(c, http.StatusOK, "diff.html", "Diff", gin.H{"Diff": analysis.Diff(from, to)})
+}
+
+// UILoginPageHandler handles the HTTP request for the sign in page.
+func (h *Handler) UILoginPageHandler(c *gin.Context) {
+	if h.Auth == nil {
+		c.Redirect(http.StatusSeeOther, "/ui/")
+		return
+	}
+	h.render(c, http.StatusOK, "login.html", "Sign in", nil)
+}
+
+// UILoginHandler handles the sign in form. A valid API key is kept in an
+// HttpOnly cookie that the dashboard routes authenticate with.
+func (h *Handler) UILoginHandler(c *gin.Context) {
+	if h.Auth == nil {
+		c.Redirect(http.StatusSeeOther, "/ui/")
+		return
+	}
+	secret := strings.TrimSpace(c.PostForm("key"))
+	key, ok := h.Auth.Authenticate(secret)
+	if !ok {
+		logger(c).Warn("Dashboard sign in failed")
+		h.render(c, http.StatusUnauthorized, "login.html", "Sign in", gin.H{"Errors": []string{"Invalid API key"}})
+		return
+	}
+	logger(c).Info("Dashboard sign in", "key_id", key.ID, "workspace", key.Workspace)
+	h.setKeyCookie(c, secret, 0)
+	c.Redirect(http.StatusSeeOther, "/ui/")
+}
+
+// UILogoutHandler handles the sign out form by clearing the key cookie.
+func (h *Handler) UILogoutHandler(c *gin.Context) {
+	h.setKeyCookie(c, "", -1)
+	c.Redirect(http.StatusSeeOther, "/ui/login")
+}
+
+// setKeyCookie sets the dashboard's API key cookie. It is strictly same-site,
+// so other sites cannot submit the dashboard's forms on the user's behalf, and
+// Secure on TLS connections or when SecureCookies is set.
+func (h *Handler) setKeyCookie(c *gin.Context, secret string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     auth.CookieName,
+		Value:    secret,
+		Path:     "/ui",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   h.SecureCookies || c.Request.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"web-analyzer/handlers"
+	"web-analyzer/models"
+	"web-analyzer/templates"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupUIRouter() (*gin.Engine, *handlers.Handler) {
+	gin.SetMode(gin.TestMode)
+	result := models.AnalysisResult{
+		URL:            "https://example.com",
+		Status:         "Completed",
+		Title:          "Example <Domain>",
+		Headings:       map[string]int{"h1": 2, "h2": 1},
+		Links:          []string{"https://example.com/ok", "https://example.com/gone"},
+		BrokenLinks:    1,
+		BrokenLinkURLs: []string{"https://example.com/gone"},
+		Score:          80,
+	}
+	old := result
+	old.Title = "Old title"
+	service := &MockAnalyzerService{
+		analysisData: map[string]models.AnalysisResult{"https://example.com": result},
+		history: map[string][]models.Snapshot{
+			"https://example.com": {{Version: 1, Result: old}, {Version: 2, Result: result}},
+		},
+	}
+	h := handlers.NewHandler(service)
+	router := gin.New()
+	router.SetHTMLTemplate(template.Must(templates.Parse()))
+	router.GET("/ui/", h.UIHandler)
+	router.GET("/ui/jobs", h.UIJobsHandler)
+	router.POST("/ui/analyze", h.UISubmitHandler)
+	router.GET("/ui/result", h.UIResultHandler)
+	router.GET("/ui/history", h.UIHistoryHandler)
+	router.GET("/ui/diff", h.UIDiffHandler)
+	return router, h
+}
+
+func TestUIResultHandler(t *testing.T) {
+	router, _ := setupUIRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/ui/result?url="+url.QueryEscape("https://example.com"), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	body := resp.Body.String()
+	assert.Contains(t, body, "Example &lt;Domain&gt;")
+	assert.Contains(t, body, "Multiple h1 headings (2)")
+	assert.Contains(t, body, "https://example.com/gone")
+	assert.Contains(t, body, "Broken")
+
+	req, _ = http.NewRequest(http.MethodGet, "/ui/result?url="+url.QueryEscape("https://unknown.com"), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestUIHistoryAndDiffHandlers(t *testing.T) {
+	router, _ := setupUIRouter()
+
+	req, _ := http.NewRequest(http.MethodGet, "/ui/history?url="+url.QueryEscape("https://example.com"), nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "from=1&amp;to=2")
+
+	req, _ = http.NewRequest(http.MethodGet, "/ui/diff?url="+url.QueryEscape("https://example.com"), nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Old title")
+
+	req, _ = http.NewRequest(http.MethodGet, "/ui/diff?url="+url.QueryEscape("https://example.com")+"&to=9", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestUISubmitHandler(t *testing.T) {
+	router, h := setupUIRouter()
+
+	submit := func(urls string) *httptest.ResponseRecorder {
+		form := url.Values{"urls": {urls}}
+		req, _ := http.NewRequest(http.MethodPost, "/ui/analyze", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	resp := submit("https://example.com\nnot a url\n")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "not a url: Invalid URL")
+	assert.Empty(t, h.Jobs.List("default", 0))
+
+	resp = submit("https://example.com\n\n  https://example.org  \n")
+	assert.Equal(t, http.StatusSeeOther, resp.Code)
+	assert.Equal(t, "/ui/", resp.Header().Get("Location"))
+	assert.Len(t, h.Jobs.List("default", 0), 2)
+
+	assert.Eventually(t, func() bool {
+		req, _ := http.NewRequest(http.MethodGet, "/ui/jobs", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return strings.Count(resp.Body.String(), "/ui/result?url=") == 2
+	}, 2*time.Second, 10*time.Millisecond)
+}
@@ -2,14 +2,60 @@ package handlers
 
 import (
 	"net/http"
-	services "web-analyzer/internal/storage"
+	"strconv"
+	"strings"
+
+	"web-analyzer/internal/listing"
 
 	"github.com/gin-gonic/gin"
 )
 
 // UrlsHandler handles the HTTP request for retrieving the list of submitted URLs.
-// It fetches the URLs from the storage service and returns them in the response.
+// It returns a page of the URLs submitted in the request's workspace, with their
+// submit count and the job, status and score of their latest analysis. URLs can
+// be filtered with the host, status, prefix and q (search) parameters and
+// ordered with sort (submitted or analyzed) and order (desc or asc). The
+// next_cursor of a page is passed as cursor to fetch the following one.
 func (h *Handler) UrlsHandler(c *gin.Context) {
-	urls := services.GetSubmittedUrls()
-	c.JSON(http.StatusOK, gin.H{"urls": urls})
+	query, msg := urlQuery(c)
+	if msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	page, err := query.Apply(h.service(c).ListUrls())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// urlQuery reads the listing query of a request. It returns the error message
+// to report when a parameter is invalid.
+func urlQuery(c *gin.Context) (listing.Query, string) {
+	sortBy, err := listing.ParseSort(c.Query("sort"))
+	if err != nil {
+		return listing.Query{}, "Invalid sort parameter"
+	}
+	query := listing.Query{
+		Host:   c.Query("host"),
+		Status: c.Query("status"),
+		Prefix: c.Query("prefix"),
+		Search: c.Query("q"),
+		Sort:   sortBy,
+		Cursor: c.Query("cursor"),
+	}
+	switch strings.ToLower(c.Query("order")) {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return listing.Query{}, "Invalid order parameter"
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit < 1 || query.Limit > listing.MaxLimit {
+			return listing.Query{}, "Invalid limit parameter"
+		}
+	}
+	return query, ""
 }
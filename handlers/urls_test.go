@@ -1,6 +1,9 @@
 package handlers_test
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,7 +18,7 @@ import (
 type MockStorage struct{}
 
 // AnalyzePage implements analyzer.AnalyzerService.
-func (m *MockStorage) AnalyzePage(url string) {
+func (m *MockStorage) AnalyzePage(ctx context.Context, url string) error {
 	panic("unimplemented")
 }
 
@@ -24,10 +27,33 @@ func (m *MockStorage) GetAnalysis(url string) (models.AnalysisResult, bool) {
 	panic("unimplemented")
 }
 
+// ListAnalyses implements analyzer.AnalyzerService.
+func (m *MockStorage) ListAnalyses() []models.AnalysisResult {
+	panic("unimplemented")
+}
+
+// GetHistory implements analyzer.AnalyzerService.
+func (m *MockStorage) GetHistory(url string) []models.Snapshot {
+	panic("unimplemented")
+}
+
 func (m *MockStorage) GetSubmittedUrls() []string {
 	return []string{"http://example.com", "http://another-example.com"}
 }
 
+func (m *MockStorage) ListUrls() []models.URLEntry {
+	var entries []models.URLEntry
+	for _, url := range m.GetSubmittedUrls() {
+		entries = append(entries, models.URLEntry{URL: url, SubmitCount: 1})
+	}
+	return entries
+}
+
+// AnalyzeDocument implements analyzer.AnalyzerService.
+func (m *MockStorage) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	panic("unimplemented")
+}
+
 func setupTestHandler() *handlers.Handler {
 	mockStorage := &MockStorage{}
 	return handlers.NewHandler(mockStorage)
@@ -50,3 +76,44 @@ func TestUrlsHandler(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "http://example.com")
 	assert.Contains(t, w.Body.String(), "http://another-example.com")
 }
+
+func TestUrlsHandler_Pagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := setupTestHandler()
+	router := gin.New()
+	router.GET("/urls", h.UrlsHandler)
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{"first page", "?limit=1&sort=submitted&order=asc", http.StatusOK},
+		{"unknown sort", "?sort=score", http.StatusBadRequest},
+		{"unknown order", "?order=sideways", http.StatusBadRequest},
+		{"limit too large", "?limit=1000", http.StatusBadRequest},
+		{"invalid cursor", "?cursor=nope", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/urls"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls?limit=1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var page struct {
+		URLs       []models.URLEntry `json:"urls"`
+		Total      int               `json:"total"`
+		NextCursor string            `json:"next_cursor"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.URLs, 1)
+	assert.Equal(t, 2, page.Total)
+	assert.NotEmpty(t, page.NextCursor)
+}
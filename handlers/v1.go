@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"web-analyzer/internal/analysis"
+	v1 "web-analyzer/internal/api/v1"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/listing"
+	"web-analyzer/internal/openapi"
+	"web-analyzer/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route is an endpoint of the versioned API. The router registers the routes
+// and the OpenAPI document is generated from them, so both stay in step.
+type Route struct {
+	Method string
+	// Path is relative to /v1 and uses Gin's ":param" syntax.
+	Path    string
+	Scope   auth.Scope
+	Handler gin.HandlerFunc
+
+	OperationID string
+	Summary     string
+	Tag         string
+	// Query lists the query parameters; path parameters are derived from Path.
+	Query []openapi.Parameter
+	// Request and Response are values of the request and response body types.
+	// Request is nil for operations without a body.
+	Request  any
+	Response any
+	// Status is the status code of a successful response.
+	Status int
+}
+
+// V1Routes returns the endpoints of the /v1 API.
+func (h *Handler) V1Routes() []Route {
+	versions := []openapi.Parameter{
+		{Name: "from", In: "query", Description: "Snapshot version to compare from, the one before to by default", Schema: &openapi.Schema{Type: "integer"}},
+		{Name: "to", In: "query", Description: "Snapshot version to compare to, the latest by default", Schema: &openapi.Schema{Type: "integer"}},
+	}
+	return []Route{
+		{
+			Method: http.MethodPost, Path: "/analyses", Scope: auth.ScopeAnalyze, Handler: h.V1AnalyzeHandler,
+			OperationID: "analyze", Summary: "Queue a URL for analysis", Tag: "analyses",
+			Request: v1.AnalyzeRequest{}, Response: v1.Job{}, Status: http.StatusAccepted,
+		},
+		{
+			Method: http.MethodGet, Path: "/jobs/:id", Scope: auth.ScopeRead, Handler: h.V1JobHandler,
+			OperationID: "getJob", Summary: "Get the state of an analysis job", Tag: "jobs",
+			Response: v1.Job{}, Status: http.StatusOK,
+		},
+		{
+			Method: http.MethodGet, Path: "/urls", Scope: auth.ScopeRead, Handler: h.V1UrlsHandler,
+			OperationID: "listUrls", Summary: "List the URLs submitted in the workspace", Tag: "urls",
+			Query: []openapi.Parameter{
+				{Name: "host", In: "query", Description: "Only URLs on this host", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "status", In: "query", Description: "Only URLs whose latest analysis has this status", Schema: &openapi.Schema{Type: "string", Enum: v1.AnalysisStatus("").Values()}},
+				{Name: "prefix", In: "query", Description: "Only URLs starting with this prefix", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "q", In: "query", Description: "Only URLs or titles containing this text", Schema: &openapi.Schema{Type: "string"}},
+				{Name: "sort", In: "query", Description: "Field to order by", Schema: &openapi.Schema{Type: "string", Enum: []string{string(listing.SortSubmitted), string(listing.SortAnalyzed)}}},
+				{Name: "order", In: "query", Schema: &openapi.Schema{Type: "string", Enum: []string{"desc", "asc"}}},
+				{Name: "limit", In: "query", Description: fmt.Sprintf("Page size, %d by default and at most %d", listing.DefaultLimit, listing.MaxLimit), Schema: &openapi.Schema{Type: "integer"}},
+				{Name: "cursor", In: "query", Description: "The next_cursor of the previous page", Schema: &openapi.Schema{Type: "string"}},
+			},
+			Response: v1.URLPage{}, Status: http.StatusOK,
+		},
+		{
+			Method: http.MethodGet, Path: "/urls/:url/analysis", Scope: auth.ScopeRead, Handler: h.V1AnalysisHandler,
+			OperationID: "getAnalysis", Summary: "Get the latest analysis of a URL", Tag: "urls",
+			Response: v1.Analysis{}, Status: http.StatusOK,
+		},
+		{
+			Method: http.MethodGet, Path: "/urls/:url/history", Scope: auth.ScopeRead, Handler: h.V1HistoryHandler,
+			OperationID: "getHistory", Summary: "List the snapshots of a URL", Tag: "urls",
+			Response: v1.History{}, Status: http.StatusOK,
+		},
+		{
+			Method: http.MethodGet, Path: "/urls/:url/diff", Scope: auth.ScopeRead, Handler: h.V1DiffHandler,
+			OperationID: "diffSnapshots", Summary: "Compare two snapshots of a URL", Tag: "urls",
+			Query: versions, Response: v1.Diff{}, Status: http.StatusOK,
+		},
+	}
+}
+
+// V1AnalyzeHandler handles the HTTP request for queueing a URL for analysis.
+// It responds with the queued job and its location.
+func (h *Handler) V1AnalyzeHandler(c *gin.Context) {
+	var req v1.AnalyzeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, http.StatusBadRequest, "Request body must be a JSON object with a url")
+		return
+	}
+	if msg := ValidateURL(req.URL); msg != "" {
+		logger(c).Warn("Rejected URL", "url", req.URL, "reason", msg)
+		problem.Abort(c, http.StatusBadRequest, msg)
+		return
+	}
+	if req.CallbackURL != "" {
+		if msg := h.ValidateCallbackURL(req.CallbackURL); msg != "" {
+			problem.Abort(c, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	logger(c).Info("URL submitted for analysis", "url", req.URL)
+	job, err := h.Jobs.SubmitWithCallback(c.Request.Context(), req.URL, req.CallbackURL)
+	if err != nil {
+		logger(c).Error("Failed to queue analysis", "url", req.URL, "error", err)
+		status, msg := submitError(err)
+		problem.Abort(c, status, msg)
+		return
+	}
+	c.Header("Location", "/v1/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, v1.NewJob(job))
+}
+
+// V1JobHandler handles the HTTP request for the state of an analysis job.
+func (h *Handler) V1JobHandler(c *gin.Context) {
+	job, ok := h.Jobs.Job(c.Param("id"))
+	if !ok || job.Workspace != h.workspace(c) {
+		problem.Abort(c, http.StatusNotFound, "Job not found")
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewJob(job))
+}
+
+// V1UrlsHandler handles the HTTP request for listing submitted URLs, with the
+// parameters of UrlsHandler. The status filter takes v1 status values.
+func (h *Handler) V1UrlsHandler(c *gin.Context) {
+	query, msg := urlQuery(c)
+	if msg != "" {
+		problem.Abort(c, http.StatusBadRequest, msg)
+		return
+	}
+	query.Status = v1.AnalysisStatus(query.Status).Legacy()
+	page, err := query.Apply(h.service(c).ListUrls())
+	if err != nil {
+		problem.Abort(c, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewURLPage(page.URLs, page.Total, page.NextCursor))
+}
+
+// V1AnalysisHandler handles the HTTP request for the latest analysis of a URL.
+// The URL is taken from the path and must be percent-encoded.
+func (h *Handler) V1AnalysisHandler(c *gin.Context) {
+	result, ok := h.service(c).GetAnalysis(c.Param("url"))
+	if !ok {
+		problem.Abort(c, http.StatusNotFound, "Analysis not found")
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAnalysis(result))
+}
+
+// V1HistoryHandler handles the HTTP request for the snapshots of a URL.
+func (h *Handler) V1HistoryHandler(c *gin.Context) {
+	url := c.Param("url")
+	history := h.service(c).GetHistory(url)
+	if len(history) == 0 {
+		problem.Abort(c, http.StatusNotFound, "No history found")
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewHistory(url, history))
+}
+
+// V1DiffHandler handles the HTTP request for comparing two snapshots of a URL,
+// selected like DiffHandler does.
+func (h *Handler) V1DiffHandler(c *gin.Context) {
+	history := h.service(c).GetHistory(c.Param("url"))
+	if len(history) == 0 {
+		problem.Abort(c, http.StatusNotFound, "No history found")
+		return
+	}
+	to, status, msg := selectSnapshot(c, "to", latestVersion(history), history)
+	if status != http.StatusOK {
+		problem.Abort(c, status, msg)
+		return
+	}
+	from, status, msg := selectSnapshot(c, "from", previousVersion(to, history), history)
+	if status != http.StatusOK {
+		problem.Abort(c, status, msg)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewDiff(analysis.Diff(from, to)))
+}
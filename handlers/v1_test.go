@@ -0,0 +1,121 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"web-analyzer/handlers"
+	v1 "web-analyzer/internal/api/v1"
+	"web-analyzer/internal/openapi"
+	"web-analyzer/internal/problem"
+	"web-analyzer/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupV1Router() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	result := models.AnalysisResult{URL: "https://example.com", Status: "Completed", Title: "New", LoginForm: "Present"}
+	old := result
+	old.Title = "Old"
+	service := &MockAnalyzerService{
+		analysisData: map[string]models.AnalysisResult{"https://example.com": result},
+		history: map[string][]models.Snapshot{
+			"https://example.com": {{Version: 1, Result: old}, {Version: 2, Result: result}},
+		},
+	}
+	h := handlers.NewHandler(service)
+	router := gin.New()
+	router.UseRawPath = true
+	router.UnescapePathValues = true
+	group := router.Group("/v1", problem.Middleware())
+	for _, route := range h.V1Routes() {
+		group.Handle(route.Method, route.Path, route.Handler)
+	}
+	router.GET("/openapi.json", h.OpenAPIHandler)
+	return router
+}
+
+func TestV1AnalysisHandler(t *testing.T) {
+	router := setupV1Router()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/urls/"+url.PathEscape("https://example.com")+"/analysis", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var analysis v1.Analysis
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &analysis))
+	assert.Equal(t, v1.AnalysisCompleted, analysis.Status)
+	assert.True(t, analysis.HasLoginForm)
+
+	req, _ = http.NewRequest(http.MethodGet, "/v1/urls/"+url.PathEscape("https://unknown.com")+"/analysis", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, problem.ContentType, resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), `"detail":"Analysis not found"`)
+}
+
+func TestV1DiffHandler(t *testing.T) {
+	router := setupV1Router()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/urls/"+url.PathEscape("https://example.com")+"/diff", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `{"field":"Title","old":"Old","new":"New"}`)
+}
+
+func TestV1AnalyzeHandler(t *testing.T) {
+	router := setupV1Router()
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"valid", `{"url": "https://example.com"}`, http.StatusAccepted},
+		{"invalid URL", `{"url": "not a url"}`, http.StatusBadRequest},
+		{"malformed body", `[]`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/v1/analyses", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+			assert.Equal(t, tt.want, resp.Code)
+			if tt.want != http.StatusAccepted {
+				assert.Equal(t, problem.ContentType, resp.Header().Get("Content-Type"))
+				return
+			}
+			var job v1.Job
+			assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &job))
+			assert.Equal(t, "/v1/jobs/"+job.ID, resp.Header().Get("Location"))
+		})
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	router := setupV1Router()
+
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var doc openapi.Document
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	for _, path := range []string{"/v1/analyses", "/v1/jobs/{id}", "/v1/urls", "/v1/urls/{url}/analysis", "/v1/urls/{url}/history", "/v1/urls/{url}/diff"} {
+		assert.Contains(t, doc.Paths, path)
+	}
+	assert.Equal(t, []string{"in_progress", "completed", "failed"}, doc.Components.Schemas["Analysis"].Properties["status"].Enum)
+	assert.Contains(t, doc.Paths["/v1/urls/{url}/analysis"]["get"].Responses, "404")
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"web-analyzer/internal/webhooks"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRequest represents the request body for registering a webhook.
+type WebhookRequest struct {
+	URL    string           `json:"url"`
+	Secret string           `json:"secret,omitempty"`
+	Events []webhooks.Event `json:"events,omitempty"`
+	Filter webhooks.Filter  `json:"filter"`
+}
+
+// WebhookResponse is returned when a webhook is registered. It is the only
+// response that includes the signing secret.
+type WebhookResponse struct {
+	webhooks.Webhook
+	Secret string `json:"secret"`
+}
+
+// CreateWebhookHandler handles the HTTP request for registering a webhook that is
+// notified whenever an analysis completes or fails.
+func (h *Handler) CreateWebhookHandler(c *gin.Context) {
+	var req WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if msg := ValidateURL(req.URL); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	for _, event := range req.Events {
+		if event != webhooks.EventCompleted && event != webhooks.EventFailed {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown event " + string(event)})
+			return
+		}
+	}
+
+	webhook, err := h.Webhooks.Register(h.workspace(c), req.URL, req.Secret, req.Events, req.Filter)
+	if err != nil {
+		logger(c).Error("Failed to register webhook", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register webhook"})
+		return
+	}
+	logger(c).Info("Webhook registered", "webhook_id", webhook.ID, "url", webhook.URL)
+	c.JSON(http.StatusCreated, WebhookResponse{Webhook: webhook, Secret: webhook.Secret})
+}
+
+// WebhooksHandler handles the HTTP request for listing registered webhooks.
+func (h *Handler) WebhooksHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"webhooks": h.Webhooks.List(h.workspace(c))})
+}
+
+// DeleteWebhookHandler handles the HTTP request for removing a webhook.
+func (h *Handler) DeleteWebhookHandler(c *gin.Context) {
+	if err := h.Webhooks.Delete(h.workspace(c), c.Param("id")); err != nil {
+		if errors.Is(err, webhooks.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete webhook"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// WebhookDeliveriesHandler handles the HTTP request for the webhook delivery log.
+func (h *Handler) WebhookDeliveriesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"deliveries": h.Webhooks.Deliveries(h.workspace(c))})
+}
@@ -0,0 +1,102 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupWebhookRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.POST("/analyze", h.AnalyzeHandler)
+	router.POST("/webhooks", h.CreateWebhookHandler)
+	router.GET("/webhooks", h.WebhooksHandler)
+	router.DELETE("/webhooks/:id", h.DeleteWebhookHandler)
+	return router
+}
+
+func TestWebhookHandlers_RegisterListDelete(t *testing.T) {
+	router := setupWebhookRouter()
+
+	body := []byte(`{"url": "https://hooks.example.com/analyzer", "filter": {"broken_links_only": true}}`)
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusCreated, resp.Code)
+
+	var created handlers.WebhookResponse
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.ID)
+	assert.NotEmpty(t, created.Secret)
+
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), created.ID)
+	assert.NotContains(t, resp.Body.String(), created.Secret)
+
+	req, _ = http.NewRequest(http.MethodDelete, "/webhooks/"+created.ID, nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestCreateWebhookHandler_UnknownEvent(t *testing.T) {
+	router := setupWebhookRouter()
+
+	body := []byte(`{"url": "https://hooks.example.com", "events": ["analysis.started"]}`)
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestAnalyzeHandler_InvalidCallbackURL(t *testing.T) {
+	router := setupWebhookRouter()
+
+	body, _ := json.Marshal(handlers.AnalyzeRequest{URL: "https://example.com", CallbackURL: "not a url"})
+	req, _ := http.NewRequest(http.MethodPost, "/analyze", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Invalid callback URL")
+}
+
+func TestAnalyzeHandler_CallbackRequiresSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(&mockAnalyzerService{})
+	router := gin.Default()
+	router.POST("/analyze", h.AnalyzeHandler)
+
+	submit := func() *httptest.ResponseRecorder {
+		body, _ := json.Marshal(handlers.AnalyzeRequest{URL: "https://example.com", CallbackURL: "https://hooks.example.com/done"})
+		req, _ := http.NewRequest(http.MethodPost, "/analyze", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// Unsigned callbacks could be forged, so they are refused.
+	resp := submit()
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "no webhook secret")
+
+	h.Webhooks.CallbackSecret = "callback-secret"
+	resp = submit()
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+}
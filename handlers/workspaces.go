@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"web-analyzer/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkspaceHandler handles the HTTP request for the quota and usage of the
+// request's workspace.
+func (h *Handler) WorkspaceHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Workspaces.Info(h.workspace(c)))
+}
+
+// WorkspacesHandler handles the HTTP request for listing workspaces with their
+// quotas and usage.
+func (h *Handler) WorkspacesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"workspaces": h.Workspaces.List()})
+}
+
+// SetQuotaHandler handles the HTTP request for changing the quota of a
+// workspace. Zero limits mean unlimited.
+func (h *Handler) SetQuotaHandler(c *gin.Context) {
+	id := c.Param("id")
+	if err := workspace.ValidateID(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid workspace"})
+		return
+	}
+	var quota workspace.Quota
+	if err := c.ShouldBindJSON(&quota); err != nil || quota.AnalysesPerDay < 0 || quota.CrawlPages < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.Workspaces.SetQuota(id, quota); err != nil {
+		logger(c).Error("Failed to save workspace quota", "workspace_id", id, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save quota"})
+		return
+	}
+	logger(c).Info("Workspace quota updated", "workspace_id", id,
+		"analyses_per_day", quota.AnalysesPerDay, "crawl_pages", quota.CrawlPages)
+	c.JSON(http.StatusOK, h.Workspaces.Info(id))
+}
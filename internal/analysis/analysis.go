@@ -1,31 +1,82 @@
 package analysis
 
 import (
+	"slices"
+	"sort"
+	"sync"
 	"web-analyzer/models"
 )
 
-var analysisResults = make(map[string]models.AnalysisResult)
+const (
+	completedStatus = "Completed"
+	// maxSnapshots is the number of snapshots kept per URL.
+	maxSnapshots = 50
+)
 
+// NewAnalysis creates an empty analysis store.
 func NewAnalysis() *Analysis {
-
-	return &Analysis{}
-
+	return &Analysis{
+		analysisResults: make(map[string]models.AnalysisResult),
+		snapshots:       make(map[string][]models.Snapshot),
+	}
 }
 
-// Storage represents a storage structure.
-
+// Analysis stores the latest result and the snapshot history of every URL.
+// Each instance keeps its own results, so separate workspaces never see each
+// other's analyses.
 type Analysis struct {
-	analysisData map[string]models.AnalysisResult
+	mu              sync.RWMutex
+	analysisResults map[string]models.AnalysisResult
+	snapshots       map[string][]models.Snapshot
 }
 
 // StoreAnalysis implements analyzer.Analysis.
-func (a Analysis) StoreAnalysis(url string, result models.AnalysisResult) {
-	analysisResults[url] = result
+// Completed results are also appended to the URL history as a new snapshot.
+// Only the latest maxSnapshots snapshots are kept; versions keep counting up
+// as older ones are dropped.
+func (a *Analysis) StoreAnalysis(url string, result models.AnalysisResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.analysisResults[url] = result
+	if result.Status != completedStatus {
+		return
+	}
+	snapshots := a.snapshots[url]
+	version := 1
+	if len(snapshots) > 0 {
+		version = snapshots[len(snapshots)-1].Version + 1
+	}
+	snapshots = append(snapshots, models.Snapshot{Version: version, Result: result})
+	if len(snapshots) > maxSnapshots {
+		snapshots = slices.Clone(snapshots[len(snapshots)-maxSnapshots:])
+	}
+	a.snapshots[url] = snapshots
 }
 
-func (a Analysis) GetAnalysis(url string) (models.AnalysisResult, bool) {
-
-	result, exists := analysisResults[url]
+func (a *Analysis) GetAnalysis(url string) (models.AnalysisResult, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	result, exists := a.analysisResults[url]
 	return result, exists
+}
+
+// ListAnalyses returns the latest analysis of every URL, ordered by URL.
+func (a *Analysis) ListAnalyses() []models.AnalysisResult {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	results := make([]models.AnalysisResult, 0, len(a.analysisResults))
+	for _, result := range a.analysisResults {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].URL < results[j].URL })
+	return results
+}
 
+// History returns the retained snapshots of url, oldest first.
+func (a *Analysis) History(url string) []models.Snapshot {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	history := make([]models.Snapshot, len(a.snapshots[url]))
+	copy(history, a.snapshots[url])
+	return history
 }
@@ -1,7 +1,10 @@
 package analysis_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"web-analyzer/internal/analysis"
 	"web-analyzer/models"
 
 	"github.com/stretchr/testify/assert"
@@ -55,3 +58,23 @@ func TestAnalysisResultHeadings(t *testing.T) {
 	assert.Equal(t, 2, result.Headings["h2"])
 	assert.Equal(t, 0, result.Headings["h3"])
 }
+
+// TestAnalysis_ConcurrentWrites exercises the store the way the job workers
+// do; run with -race.
+func TestAnalysis_ConcurrentWrites(t *testing.T) {
+	store := analysis.NewAnalysis()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://example.com/%d", i%2)
+			store.StoreAnalysis(url, models.AnalysisResult{URL: url, Status: "Completed"})
+			store.GetAnalysis(url)
+			store.ListAnalyses()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, store.ListAnalyses(), 2)
+	assert.Len(t, store.History("https://example.com/0"), 4)
+}
@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"web-analyzer/models"
+)
+
+// Diff compares two snapshots of the same URL.
+func Diff(from, to models.Snapshot) models.SnapshotDiff {
+	a, b := from.Result, to.Result
+	diff := models.SnapshotDiff{
+		URL:              b.URL,
+		FromVersion:      from.Version,
+		ToVersion:        to.Version,
+		Changes:          []models.FieldChange{},
+		LinksAdded:       difference(b.Links, a.Links),
+		LinksRemoved:     difference(a.Links, b.Links),
+		NewlyBrokenLinks: difference(b.BrokenLinkURLs, a.BrokenLinkURLs),
+		FixedLinks:       difference(a.BrokenLinkURLs, b.BrokenLinkURLs),
+	}
+
+	compare := func(field, old, new string) {
+		if old != new {
+			diff.Changes = append(diff.Changes, models.FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	compare("Status", a.Status, b.Status)
+	compare("HTML Version", a.HTMLVersion, b.HTMLVersion)
+	compare("Title", a.Title, b.Title)
+	for _, level := range headingKeys(a.Headings, b.Headings) {
+		compare(level+" count", strconv.Itoa(a.Headings[level]), strconv.Itoa(b.Headings[level]))
+	}
+	compare("Internal Links", strconv.Itoa(a.InternalLinks), strconv.Itoa(b.InternalLinks))
+	compare("External Links", strconv.Itoa(a.ExternalLinks), strconv.Itoa(b.ExternalLinks))
+	compare("Broken Links", strconv.Itoa(a.BrokenLinks), strconv.Itoa(b.BrokenLinks))
+	compare("Login Form", a.LoginForm, b.LoginForm)
+	compare("Final URL", a.FinalURL, b.FinalURL)
+	compare("Redirects", fmt.Sprint(redirects(a)), fmt.Sprint(redirects(b)))
+
+	return diff
+}
+
+func redirects(r models.AnalysisResult) int {
+	if len(r.RedirectChain) == 0 {
+		return 0
+	}
+	return len(r.RedirectChain) - 1
+}
+
+func headingKeys(a, b map[string]int) []string {
+	seen := make(map[string]bool)
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	out := []string{}
+	for _, s := range a {
+		if !inB[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package analysis_test
+
+import (
+	"testing"
+	"web-analyzer/internal/analysis"
+	"web-analyzer/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	from := models.Snapshot{Version: 1, Result: models.AnalysisResult{
+		URL:            "https://example.com",
+		Status:         "Completed",
+		Title:          "Old",
+		HTMLVersion:    "HTML5",
+		Headings:       map[string]int{"h1": 1},
+		Links:          []string{"https://example.com/a", "https://example.com/b"},
+		BrokenLinkURLs: []string{"https://example.com/b"},
+		BrokenLinks:    1,
+	}}
+	to := models.Snapshot{Version: 2, Result: models.AnalysisResult{
+		URL:            "https://example.com",
+		Status:         "Completed",
+		Title:          "New",
+		HTMLVersion:    "HTML5",
+		Headings:       map[string]int{"h1": 2},
+		Links:          []string{"https://example.com/a", "https://example.com/c"},
+		BrokenLinkURLs: []string{"https://example.com/c"},
+		BrokenLinks:    1,
+	}}
+
+	diff := analysis.Diff(from, to)
+
+	assert.Equal(t, 1, diff.FromVersion)
+	assert.Equal(t, 2, diff.ToVersion)
+	assert.Contains(t, diff.Changes, models.FieldChange{Field: "Title", Old: "Old", New: "New"})
+	assert.Contains(t, diff.Changes, models.FieldChange{Field: "h1 count", Old: "1", New: "2"})
+	assert.NotContains(t, diff.Changes, models.FieldChange{Field: "Broken Links", Old: "1", New: "1"})
+	assert.Equal(t, []string{"https://example.com/c"}, diff.LinksAdded)
+	assert.Equal(t, []string{"https://example.com/b"}, diff.LinksRemoved)
+	assert.Equal(t, []string{"https://example.com/c"}, diff.NewlyBrokenLinks)
+	assert.Equal(t, []string{"https://example.com/b"}, diff.FixedLinks)
+}
+
+func TestStoreAnalysis_KeepsCompletedSnapshots(t *testing.T) {
+	a := analysis.NewAnalysis()
+	url := "https://history.example.com"
+
+	a.StoreAnalysis(url, models.AnalysisResult{URL: url, Status: "In progress"})
+	a.StoreAnalysis(url, models.AnalysisResult{URL: url, Status: "Completed", Title: "First"})
+	a.StoreAnalysis(url, models.AnalysisResult{URL: url, Status: "Completed", Title: "Second"})
+
+	history := a.History(url)
+	assert.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].Version)
+	assert.Equal(t, "First", history[0].Result.Title)
+	assert.Equal(t, "Second", history[1].Result.Title)
+}
+
+func TestStoreAnalysis_CapsSnapshots(t *testing.T) {
+	a := analysis.NewAnalysis()
+	url := "https://busy.example.com"
+
+	for i := 0; i < 60; i++ {
+		a.StoreAnalysis(url, models.AnalysisResult{URL: url, Status: "Completed"})
+	}
+
+	history := a.History(url)
+	assert.Len(t, history, 50)
+	assert.Equal(t, 11, history[0].Version)
+	assert.Equal(t, 60, history[49].Version)
+}
@@ -1,31 +1,80 @@
 package analyzer
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"web-analyzer/internal/fingerprint"
+	"web-analyzer/internal/performance"
 	"web-analyzer/models"
 )
 
 type AnalyzerService interface {
-	AnalyzePage(url string)
+	AnalyzePage(ctx context.Context, url string) error
+	AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error)
 	GetAnalysis(url string) (models.AnalysisResult, bool)
+	ListAnalyses() []models.AnalysisResult
+	GetHistory(url string) []models.Snapshot
+	GetSubmittedUrls() []string
+	ListUrls() []models.URLEntry
 }
 
 type Analysis interface {
 	StoreAnalysis(url string, result models.AnalysisResult)
 	GetAnalysis(url string) (models.AnalysisResult, bool)
+	ListAnalyses() []models.AnalysisResult
+	History(url string) []models.Snapshot
 }
 
 type Analyzer struct {
 	Storage     Storage
 	LinkChecker LinkChecker
 	Analysis    Analysis
+
+	// HTTPClient is used to fetch pages. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+	// RedirectThreshold is the number of redirects above which a result is
+	// flagged as having a long redirect chain. Defaults to 3 when zero.
+	RedirectThreshold int
+	// Fingerprints detects the technologies of analysed pages.
+	// fingerprint.Default() is used when nil.
+	Fingerprints *fingerprint.Set
+	// Performance configures the page weight audit. Subresources are only
+	// fetched when Performance.FetchResources is set.
+	Performance performance.Options
+}
+
+func (a *Analyzer) fingerprints() *fingerprint.Set {
+	if a.Fingerprints != nil {
+		return a.Fingerprints
+	}
+	return fingerprint.Default()
+}
+
+func (a *Analyzer) httpClient() *http.Client {
+	if a.HTTPClient != nil {
+		return a.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (a *Analyzer) redirectThreshold() int {
+	if a.RedirectThreshold > 0 {
+		return a.RedirectThreshold
+	}
+	return defaultRedirectThreshold
 }
 
 type Storage interface {
 	AddSubmittedUrl(url string)
+	GetSubmittedUrls() []string
+	// UpdateUrl applies fn to the entry of a submitted URL.
+	UpdateUrl(url string, fn func(*models.URLEntry))
+	ListUrls() []models.URLEntry
 }
 
 type LinkChecker interface {
-	IsBroken(url string) bool
+	IsBroken(ctx context.Context, url string) bool
 }
 
 type AnalysisResult struct {
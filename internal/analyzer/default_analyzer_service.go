@@ -1,15 +1,44 @@
 package analyzer
 
 import (
-	"log/slog"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	neturl "net/url"
 	"strings"
+	"time"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/metrics"
+	"web-analyzer/internal/performance"
+	"web-analyzer/internal/security"
+	"web-analyzer/internal/structureddata"
+	"web-analyzer/internal/tracing"
 	"web-analyzer/models"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/html"
 )
 
-const inProgress = "In progress"
+const (
+	inProgress      = "In progress"
+	completedStatus = "Completed"
+	failedStatus    = "Failed"
+)
+
+var (
+	// ErrUnexpectedStatus is returned when the page responds with a status
+	// other than 200 OK.
+	ErrUnexpectedStatus = errors.New("unexpected status")
+	// ErrNotHTML is returned when the page is not an HTML document.
+	ErrNotHTML = errors.New("not an HTML document")
+
+	errParse = errors.New("parsing HTML")
+)
 
 type DefaultAnalyzerService struct {
 	Analyzer *Analyzer
@@ -19,34 +48,183 @@ func (d DefaultAnalyzerService) GetAnalysis(url string) (models.AnalysisResult,
 	return d.Analyzer.Analysis.GetAnalysis(url)
 }
 
-func (d DefaultAnalyzerService) AnalyzePage(url string) {
+func (d DefaultAnalyzerService) ListAnalyses() []models.AnalysisResult {
+	return d.Analyzer.Analysis.ListAnalyses()
+}
+
+func (d DefaultAnalyzerService) GetHistory(url string) []models.Snapshot {
+	return d.Analyzer.Analysis.History(url)
+}
+
+func (d DefaultAnalyzerService) GetSubmittedUrls() []string {
+	return d.Analyzer.Storage.GetSubmittedUrls()
+}
+
+func (d DefaultAnalyzerService) ListUrls() []models.URLEntry {
+	return d.Analyzer.Storage.ListUrls()
+}
+
+// AnalyzePage analyses url and records the submission and its outcome in the
+// URL's storage entry.
+func (d DefaultAnalyzerService) AnalyzePage(ctx context.Context, url string) error {
 	d.Analyzer.Storage.AddSubmittedUrl(url)
-	slog.Info("AnalyzePage called", "url", url)
+	jobID := jobIDFromContext(ctx)
+	d.Analyzer.Storage.UpdateUrl(url, func(entry *models.URLEntry) {
+		entry.LastJobID = jobID
+		entry.LastStatus = inProgress
+		entry.LastError = ""
+	})
+	err := d.analyzePage(ctx, url)
+	d.recordOutcome(url, err)
+	return err
+}
 
-	resp, err := http.Get(url)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		slog.Error("Error fetching or invalid status", "url", url, "status", resp.StatusCode, "error", err)
-		return
+// recordOutcome stores the status, score and title of the latest analysis of
+// url in its storage entry.
+func (d DefaultAnalyzerService) recordOutcome(url string, err error) {
+	result, ok := d.Analyzer.Analysis.GetAnalysis(url)
+	d.Analyzer.Storage.UpdateUrl(url, func(entry *models.URLEntry) {
+		analyzedAt := time.Now()
+		if err != nil {
+			entry.LastStatus = failedStatus
+			entry.LastError = err.Error()
+			entry.LastAnalyzedAt = &analyzedAt
+			return
+		}
+		entry.LastStatus = completedStatus
+		if ok {
+			entry.LastStatus = result.Status
+			entry.LastScore = result.Score
+			entry.LastTitle = result.Title
+			analyzedAt = result.AnalyzedAt
+		}
+		entry.LastAnalyzedAt = &analyzedAt
+	})
+}
+
+func (d DefaultAnalyzerService) analyzePage(ctx context.Context, url string) error {
+	logger := logging.FromContext(ctx)
+	logger.Info("AnalyzePage called", "url", url)
+
+	reportProgress(ctx, ProgressEvent{Type: EventFetchStarted, URL: url})
+	fetchStart := time.Now()
+	fetchCtx, fetchSpan := tracing.Tracer().Start(ctx, "fetch", trace.WithAttributes(attribute.String("url.full", url)))
+	fetched, err := fetchPage(fetchCtx, d.Analyzer.httpClient(), url)
+	metrics.ObserveSince(metrics.FetchDuration, fetchStart)
+	endFetchSpan(fetchSpan, fetched, err)
+	if err != nil {
+		logger.Error("Error fetching page", "url", url, "error", err)
+		// Redirect and certificate problems are findings about the site, so
+		// they are kept as a failed analysis rather than only logged.
+		tlsFailure := security.VerificationFailure(err)
+		if errors.Is(err, ErrRedirectLoop) || errors.Is(err, ErrTooManyRedirects) || tlsFailure != nil {
+			failed := models.AnalysisResult{
+				URL:        url,
+				Status:     failedStatus,
+				Headings:   make(map[string]int),
+				Message:    err.Error(),
+				AnalyzedAt: time.Now(),
+			}
+			if tlsFailure != nil {
+				failed.Security = &models.Security{TLS: tlsFailure}
+			}
+			d.Analyzer.applyRedirectFlags(&failed, fetched)
+			d.Analyzer.Analysis.StoreAnalysis(url, failed)
+		}
+		return fmt.Errorf("fetching page: %w", err)
 	}
+	resp := fetched.Response
 	defer resp.Body.Close()
+	reportProgress(ctx, ProgressEvent{Type: EventFetchFinished, URL: fetched.FinalURL, StatusCode: resp.StatusCode})
 
-	doc, err := html.Parse(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		logger.Error("Invalid status", "url", url, "status", resp.StatusCode)
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	// html.Parse accepts any input, so without this check a plain text or
+	// binary response would be stored as an empty HTML analysis.
+	if !isHTMLContent(resp.Header.Get("Content-Type")) {
+		logger.Error("Not an HTML document", "url", url, "content_type", resp.Header.Get("Content-Type"))
+		return fmt.Errorf("%w: %s", ErrNotHTML, resp.Header.Get("Content-Type"))
+	}
+
+	_, parseSpan := tracing.Tracer().Start(ctx, "parse")
+	body := &countingReader{r: resp.Body}
+	doc, err := html.Parse(body)
 	if err != nil {
-		slog.Error("Error parsing HTML", "error", err)
-		return
+		parseSpan.RecordError(err)
+		parseSpan.SetStatus(codes.Error, "parse failed")
+		parseSpan.End()
+		logger.Error("Error parsing HTML", "error", err)
+		return fmt.Errorf("%w: %w", errParse, err)
 	}
+	parseSpan.End()
+	reportProgress(ctx, ProgressEvent{Type: EventParseDone, URL: fetched.FinalURL})
 
 	inProgressResult := models.AnalysisResult{
-		Status:    inProgress,
-		Headings:  make(map[string]int),
-		LoginForm: "Not Present",
+		URL:        url,
+		Status:     inProgress,
+		Headings:   make(map[string]int),
+		LoginForm:  "Not Present",
+		AnalyzedAt: time.Now(),
 	}
 
 	d.Analyzer.Analysis.StoreAnalysis(url, inProgressResult)
 
-	result := d.Analyzer.AnalyzeHTML(doc, url)
-	slog.Info("Analysis Result", "result", result)
+	result := d.Analyzer.AnalyzeHTMLContext(ctx, doc, fetched.FinalURL)
+	d.Analyzer.applyRedirectFlags(&result, fetched)
+	result.Technologies = d.Analyzer.fingerprints().Detect(doc, resp.Header)
+	result.Security = security.Audit(resp, doc, fetched.FinalURL)
+	result.Performance = performance.Audit(ctx, d.Analyzer.httpClient(), d.Analyzer.Performance, performance.Page{
+		URL:        fetched.FinalURL,
+		Doc:        doc,
+		Header:     resp.Header,
+		Bytes:      body.n,
+		Compressed: resp.Uncompressed,
+		Resources:  result.Resources.Items,
+	})
+	result.URL = url
+	result.Score = Score(result)
+	result.AnalyzedAt = time.Now()
+	logger.Info("Analysis Result", "result", result)
+	var previous *models.Snapshot
+	if history := d.Analyzer.Analysis.History(url); len(history) > 0 {
+		previous = &history[len(history)-1]
+	}
 	d.Analyzer.Analysis.StoreAnalysis(url, result)
+	ReportResult(ctx, result, previous)
+	return nil
+}
+
+// AnalyzeDocument analyses the raw HTML read from r without fetching anything
+// but its links. Relative links resolve against baseURL; when it is empty they
+// are counted but not checked. The result is not stored.
+func (d DefaultAnalyzerService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	_, parseSpan := tracing.Tracer().Start(ctx, "parse")
+	body := &countingReader{r: r}
+	doc, err := html.Parse(body)
+	if err != nil {
+		parseSpan.RecordError(err)
+		parseSpan.SetStatus(codes.Error, "parse failed")
+		parseSpan.End()
+		return models.AnalysisResult{}, fmt.Errorf("%w: %w", errParse, err)
+	}
+	parseSpan.End()
+	reportProgress(ctx, ProgressEvent{Type: EventParseDone, URL: baseURL})
+
+	result := d.Analyzer.AnalyzeHTMLContext(ctx, doc, baseURL)
+	result.Technologies = d.Analyzer.fingerprints().Detect(doc, nil)
+	// Subresources are not fetched for uploads.
+	result.Performance = performance.Audit(ctx, nil, d.Analyzer.Performance, performance.Page{
+		URL:       baseURL,
+		Doc:       doc,
+		Bytes:     body.n,
+		Resources: result.Resources.Items,
+	})
+	result.URL = baseURL
+	result.Score = Score(result)
+	result.AnalyzedAt = time.Now()
+	return result, nil
 }
 
 func NewAnalyzerService(storage Storage, linkChecker LinkChecker, analysis Analysis) AnalyzerService {
@@ -59,13 +237,26 @@ func NewAnalyzerService(storage Storage, linkChecker LinkChecker, analysis Analy
 	}
 }
 
+// AnalyzeHTML analyses a parsed document whose relative links resolve against
+// baseURL.
 func (a *Analyzer) AnalyzeHTML(doc *html.Node, baseURL string) models.AnalysisResult {
+	return a.AnalyzeHTMLContext(context.Background(), doc, baseURL)
+}
+
+// AnalyzeHTMLContext is like AnalyzeHTML and reports link check progress to the
+// ProgressFunc carried by ctx.
+func (a *Analyzer) AnalyzeHTMLContext(ctx context.Context, doc *html.Node, baseURL string) models.AnalysisResult {
 	var title string
 	headings := map[string]int{}
 	internalLinks, externalLinks, brokenLinks := 0, 0, 0
 	loginForm := "Not Present"
 
 	seenLinks := make(map[string]bool)
+	var links, brokenLinkURLs []string
+	base, err := neturl.Parse(baseURL)
+	if err != nil {
+		base = &neturl.URL{}
+	}
 
 	var hasPasswordInput func(*html.Node) bool
 	hasPasswordInput = func(n *html.Node) bool {
@@ -84,9 +275,11 @@ func (a *Analyzer) AnalyzeHTML(doc *html.Node, baseURL string) models.AnalysisRe
 		return false
 	}
 
+	resources := newResourceCollector(base)
 	var traverse func(*html.Node)
 	traverse = func(n *html.Node) {
 		if n.Type == html.ElementNode {
+			resources.visit(n)
 			switch n.Data {
 			case "title":
 				if n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
@@ -97,22 +290,17 @@ func (a *Analyzer) AnalyzeHTML(doc *html.Node, baseURL string) models.AnalysisRe
 			case "a":
 				for _, attr := range n.Attr {
 					if attr.Key == "href" {
-						href := attr.Val
-						fullURL := href
-						isExternal := strings.HasPrefix(href, "http")
+						fullURL, isExternal := resolveLink(base, attr.Val)
 
 						if !isExternal {
 							internalLinks++
-							fullURL = baseURL + href
 						} else {
 							externalLinks++
 						}
 
 						if !seenLinks[fullURL] {
 							seenLinks[fullURL] = true
-							if a.LinkChecker.IsBroken(fullURL) {
-								brokenLinks++
-							}
+							links = append(links, fullURL)
 						}
 					}
 				}
@@ -128,16 +316,161 @@ func (a *Analyzer) AnalyzeHTML(doc *html.Node, baseURL string) models.AnalysisRe
 	}
 	traverse(doc)
 
+	// Relative links stay unresolved when there is no base URL, e.g. for
+	// uploaded documents, and cannot be checked.
+	checkable := make([]string, 0, len(links))
+	for _, link := range links {
+		if u, err := neturl.Parse(link); err == nil && u.Host != "" {
+			checkable = append(checkable, link)
+		}
+	}
+	checkable = limitChecks(ctx, checkable, 0)
+	checked := make(map[string]bool, len(checkable))
+	for i, link := range checkable {
+		broken := a.checkLink(ctx, link)
+		checked[link] = broken
+		if broken {
+			brokenLinks++
+			brokenLinkURLs = append(brokenLinkURLs, link)
+		}
+		reportProgress(ctx, ProgressEvent{Type: EventLinkChecked, Link: link, Broken: broken, Checked: i + 1, Total: len(checkable)})
+	}
+	a.checkResources(ctx, &resources.resources, checked)
+
 	return models.AnalysisResult{
-		Status:        "Completed",
-		HTMLVersion:   DetectHTMLVersion(doc),
-		Title:         title,
-		Headings:      headings,
-		InternalLinks: internalLinks,
-		ExternalLinks: externalLinks,
-		BrokenLinks:   brokenLinks,
-		LoginForm:     loginForm,
+		Status:         "Completed",
+		HTMLVersion:    DetectHTMLVersion(doc),
+		Title:          title,
+		Headings:       headings,
+		InternalLinks:  internalLinks,
+		ExternalLinks:  externalLinks,
+		BrokenLinks:    brokenLinks,
+		BrokenLinkURLs: brokenLinkURLs,
+		Links:          links,
+		LoginForm:      loginForm,
+		Resources:      &resources.resources,
+		StructuredData: structureddata.Extract(doc),
+	}
+}
+
+// checkResources checks the absolute resource URLs like links, reusing the
+// outcome of URLs already checked as links.
+func (a *Analyzer) checkResources(ctx context.Context, resources *models.Resources, checked map[string]bool) {
+	var pending []string
+	for _, r := range resources.Items {
+		if _, ok := checked[r.URL]; !ok {
+			if u, err := neturl.Parse(r.URL); err == nil && u.Host != "" {
+				checked[r.URL] = false
+				pending = append(pending, r.URL)
+			}
+		}
+	}
+	pending = limitChecks(ctx, pending, len(checked)-len(pending))
+	for i, url := range pending {
+		broken := a.checkLink(ctx, url)
+		checked[url] = broken
+		reportProgress(ctx, ProgressEvent{Type: EventResourceChecked, Link: url, Broken: broken, Checked: i + 1, Total: len(pending)})
+	}
+	for i, r := range resources.Items {
+		if checked[r.URL] {
+			resources.Items[i].Broken = true
+			resources.Broken++
+		}
+	}
+}
+
+// checkLink reports whether link is broken, recording link check metrics and a
+// span.
+func (a *Analyzer) checkLink(ctx context.Context, link string) bool {
+	ctx, span := tracing.Tracer().Start(ctx, "link_check", trace.WithAttributes(attribute.String("url.full", link)))
+	defer span.End()
+	metrics.LinkChecksInFlight.Inc()
+	defer metrics.LinkChecksInFlight.Dec()
+	defer metrics.ObserveSince(metrics.LinkCheckDuration, time.Now())
+	broken := a.LinkChecker.IsBroken(ctx, link)
+	span.SetAttributes(attribute.Bool("link.broken", broken))
+	return broken
+}
+
+// endFetchSpan records the outcome of a page fetch on span and ends it.
+func endFetchSpan(span trace.Span, fetched *fetchResult, err error) {
+	defer span.End()
+	if fetched != nil {
+		span.SetAttributes(attribute.Int("redirects", fetched.Redirects()))
+		if fetched.FinalURL != "" {
+			span.SetAttributes(attribute.String("url.final", fetched.FinalURL))
+		}
+		if fetched.Response != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", fetched.Response.StatusCode))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, FailureReason(err))
+	}
+}
+
+// FailureReason classifies an error returned by AnalyzePage into a short,
+// stable label suitable for metrics.
+func FailureReason(err error) string {
+	var netErr interface{ Timeout() bool }
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrRedirectLoop):
+		return "redirect_loop"
+	case errors.Is(err, ErrTooManyRedirects):
+		return "too_many_redirects"
+	case errors.Is(err, ErrUnexpectedStatus):
+		return "unexpected_status"
+	case errors.Is(err, ErrNotHTML):
+		return "not_html"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return "cancelled"
+	case security.VerificationFailure(err) != nil:
+		return "tls_error"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, errParse):
+		return "parse_error"
+	}
+	return "fetch_error"
+}
+
+// resolveLink resolves href against the page base URL and reports whether it
+// points to a different host than the page itself.
+func resolveLink(base *neturl.URL, href string) (string, bool) {
+	ref, err := neturl.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return href, !strings.HasPrefix(href, "/")
+	}
+	abs := base.ResolveReference(ref)
+	return abs.String(), abs.Host != base.Host
+}
+
+// applyRedirectFlags copies the redirect chain of a fetch into result and sets
+// the loop, downgrade and long chain flags.
+func (a *Analyzer) applyRedirectFlags(result *models.AnalysisResult, fetched *fetchResult) {
+	result.RedirectChain = fetched.Chain
+	result.RedirectLoop = fetched.Loop
+	result.HTTPSDowngrade = fetched.Downgrade
+	result.LongRedirectChain = fetched.Redirects() > a.redirectThreshold()
+	if fetched.FinalURL != "" {
+		result.FinalURL = fetched.FinalURL
+	}
+}
+
+// isHTMLContent reports whether a Content-Type header denotes an HTML document.
+// A missing header is treated as HTML.
+func isHTMLContent(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
 	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
 }
 
 func DetectHTMLVersion(doc *html.Node) string {
@@ -1,25 +1,52 @@
 package analyzer
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"web-analyzer/internal/performance"
 	"web-analyzer/models"
+
+	"golang.org/x/net/html"
 )
 
 type mockStorage struct {
 	submittedUrls map[string]bool
+	entries       map[string]models.URLEntry
 }
 
 func (m *mockStorage) AddSubmittedUrl(url string) {
 	m.submittedUrls[url] = true
 }
 
+func (m *mockStorage) UpdateUrl(url string, fn func(*models.URLEntry)) {
+	if m.entries == nil {
+		m.entries = make(map[string]models.URLEntry)
+	}
+	entry := m.entries[url]
+	fn(&entry)
+	m.entries[url] = entry
+}
+
+func (m *mockStorage) ListUrls() []models.URLEntry { return nil }
+
+func (m *mockStorage) GetSubmittedUrls() []string {
+	urls := make([]string, 0, len(m.submittedUrls))
+	for url := range m.submittedUrls {
+		urls = append(urls, url)
+	}
+	return urls
+}
+
 type mockLinkChecker struct {
 	brokenLinks map[string]bool
 }
 
-func (m *mockLinkChecker) IsBroken(url string) bool {
+func (m *mockLinkChecker) IsBroken(ctx context.Context, url string) bool {
 	return m.brokenLinks[url]
 }
 
@@ -32,6 +59,18 @@ func (m *mockAnalysis) GetAnalysis(url string) (models.AnalysisResult, bool) {
 	return result, exists
 }
 
+func (m *mockAnalysis) ListAnalyses() []models.AnalysisResult {
+	results := make([]models.AnalysisResult, 0, len(m.analysisResults))
+	for _, result := range m.analysisResults {
+		results = append(results, result)
+	}
+	return results
+}
+
+func (m *mockAnalysis) History(url string) []models.Snapshot {
+	return nil
+}
+
 func (m *mockAnalysis) StoreAnalysis(url string, result models.AnalysisResult) {
 	m.analysisResults[url] = result
 }
@@ -73,7 +112,7 @@ func TestAnalyzePage_Success(t *testing.T) {
 	}))
 	defer server.Close()
 
-	service.AnalyzePage(server.URL)
+	service.AnalyzePage(context.Background(), server.URL)
 
 	result, exists := mockAnalysis.GetAnalysis(server.URL)
 	if !exists {
@@ -112,7 +151,7 @@ func TestAnalyzePage_FetchError(t *testing.T) {
 
 	invalidURL := "http://invalid-url"
 
-	service.AnalyzePage(invalidURL)
+	service.AnalyzePage(context.Background(), invalidURL)
 
 	_, exists := mockAnalysis.GetAnalysis(invalidURL)
 	if exists {
@@ -139,10 +178,261 @@ func TestAnalyzePage_HTMLParseError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	service.AnalyzePage(server.URL)
+	service.AnalyzePage(context.Background(), server.URL)
 
 	_, exists := mockAnalysis.GetAnalysis(server.URL)
 	if exists {
 		t.Fatalf("Expected no analysis result for invalid HTML")
 	}
 }
+
+func TestFailureReason(t *testing.T) {
+	tests := map[string]error{
+		"redirect_loop":     fmt.Errorf("fetching page: %w", ErrRedirectLoop),
+		"unexpected_status": fmt.Errorf("%w: 404", ErrUnexpectedStatus),
+		"not_html":          fmt.Errorf("%w: text/plain", ErrNotHTML),
+		"fetch_error":       errors.New("dial tcp: no such host"),
+	}
+	for want, err := range tests {
+		if got := FailureReason(err); got != want {
+			t.Errorf("FailureReason(%v) = %q; want %q", err, got, want)
+		}
+	}
+}
+
+func TestAnalyzePage_RecordsOutcome(t *testing.T) {
+	storage := &mockStorage{submittedUrls: make(map[string]bool)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     storage,
+			LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)},
+			Analysis:    &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("<html><head><title>Recorded</title></head><body></body></html>"))
+	}))
+	defer server.Close()
+
+	if err := service.AnalyzePage(WithJobID(context.Background(), "job-1"), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry := storage.entries[server.URL]
+	if entry.LastJobID != "job-1" || entry.LastStatus != "Completed" || entry.LastTitle != "Recorded" || entry.LastAnalyzedAt == nil {
+		t.Errorf("unexpected entry after success: %+v", entry)
+	}
+
+	missing := server.URL + "/missing"
+	if err := service.AnalyzePage(WithJobID(context.Background(), "job-2"), missing); err == nil {
+		t.Fatal("expected an error for a missing page")
+	}
+	entry = storage.entries[missing]
+	if entry.LastJobID != "job-2" || entry.LastStatus != "Failed" || entry.LastError == "" {
+		t.Errorf("unexpected entry after failure: %+v", entry)
+	}
+}
+
+func TestAnalyzeDocument(t *testing.T) {
+	checker := &mockLinkChecker{brokenLinks: map[string]bool{"https://example.com/missing": true}}
+	analysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: checker,
+			Analysis:    analysis,
+		},
+	}
+	doc := `<!DOCTYPE html><title>Local</title><a href="/missing">a</a><a href="https://other.com/">b</a>`
+
+	result, err := service.AnalyzeDocument(context.Background(), strings.NewReader(doc), "https://example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Title != "Local" || result.URL != "https://example.com/" || result.BrokenLinks != 1 || result.Score == 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(analysis.analysisResults) != 0 {
+		t.Error("expected document analyses not to be stored")
+	}
+
+	result, err = service.AnalyzeDocument(context.Background(), strings.NewReader(doc), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.InternalLinks != 1 || result.BrokenLinks != 0 {
+		t.Errorf("expected relative links to be counted but not checked without a base URL, got %+v", result)
+	}
+}
+
+func TestAnalyzePage_DetectsTechnologies(t *testing.T) {
+	mockAnalysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)},
+			Analysis:    mockAnalysis,
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Powered-By", "PHP/8.2.1")
+		w.Write([]byte(`<html><head><meta name="generator" content="WordPress 6.4"></head></html>`))
+	}))
+	defer server.Close()
+
+	if err := service.AnalyzePage(context.Background(), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, _ := mockAnalysis.GetAnalysis(server.URL)
+	want := []models.Technology{
+		{Name: "PHP", Category: "Programming language", Version: "8.2.1"},
+		{Name: "WordPress", Category: "CMS", Version: "6.4"},
+	}
+	if fmt.Sprint(result.Technologies) != fmt.Sprint(want) {
+		t.Errorf("expected technologies %v, got %v", want, result.Technologies)
+	}
+}
+
+func TestAnalyzePage_AuditsSecurity(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Write([]byte(`<html><body><img src="http://example.com/logo.png"></body></html>`))
+	}))
+	defer server.Close()
+
+	mockAnalysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)},
+			Analysis:    mockAnalysis,
+			HTTPClient:  server.Client(),
+		},
+	}
+	if err := service.AnalyzePage(context.Background(), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, _ := mockAnalysis.GetAnalysis(server.URL)
+	sec := result.Security
+	if sec == nil || sec.TLS == nil {
+		t.Fatalf("expected a security audit with TLS details, got %+v", sec)
+	}
+	if len(sec.MixedContent) != 1 || sec.MixedContent[0] != "http://example.com/logo.png" {
+		t.Errorf("expected the image as mixed content, got %v", sec.MixedContent)
+	}
+	for _, h := range sec.Headers {
+		want := models.HeaderMissing
+		if h.Name == "X-Content-Type-Options" {
+			want = models.HeaderGood
+		}
+		if h.Status != want {
+			t.Errorf("expected %s to be %s, got %s", h.Name, want, h.Status)
+		}
+	}
+}
+
+func TestAnalyzePage_ReportsCertificateFailure(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html></html>`))
+	}))
+	defer server.Close()
+
+	// The default client does not trust the self-signed test certificate.
+	mockAnalysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)},
+			Analysis:    mockAnalysis,
+			HTTPClient:  &http.Client{},
+		},
+	}
+	err := service.AnalyzePage(context.Background(), server.URL)
+	if got := FailureReason(err); got != "tls_error" {
+		t.Fatalf("FailureReason(%v) = %q; want tls_error", err, got)
+	}
+
+	result, ok := mockAnalysis.GetAnalysis(server.URL)
+	if !ok || result.Status != failedStatus {
+		t.Fatalf("expected a failed analysis, got %+v", result)
+	}
+	if result.Security == nil || result.Security.TLS == nil {
+		t.Fatalf("expected TLS details, got %+v", result.Security)
+	}
+	tlsInfo := result.Security.TLS
+	if len(tlsInfo.Issues) != 1 || !strings.HasPrefix(tlsInfo.Issues[0], "TLS certificate verification failed") {
+		t.Errorf("unexpected TLS issues: %v", tlsInfo.Issues)
+	}
+	if tlsInfo.CertificateExpiry.IsZero() {
+		t.Error("expected the details of the unverified certificate")
+	}
+}
+
+func TestAnalyzePage_MeasuresPerformance(t *testing.T) {
+	page := `<html><head><script src="/app.js"></script></head><body><img src="/logo.png"></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app.js":
+			w.Header().Set("Cache-Control", "max-age=3600")
+			w.Write([]byte("console.log(1)"))
+		case "/logo.png":
+			w.Write(make([]byte, 100))
+		default:
+			w.Write([]byte(page))
+		}
+	}))
+	defer server.Close()
+
+	mockAnalysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)},
+			Analysis:    mockAnalysis,
+			HTTPClient:  server.Client(),
+			Performance: performance.Options{FetchResources: true},
+		},
+	}
+	if err := service.AnalyzePage(context.Background(), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, _ := mockAnalysis.GetAnalysis(server.URL)
+	perf := result.Performance
+	if perf == nil || !perf.Fetched {
+		t.Fatalf("expected a performance audit with fetched resources, got %+v", perf)
+	}
+	if perf.DocumentBytes != int64(len(page)) || perf.TotalBytes != int64(len(page))+14+100 || perf.Requests != 3 {
+		t.Errorf("unexpected sizes: %+v", perf)
+	}
+	if len(perf.RenderBlocking) != 1 || len(perf.ImagesWithoutDimensions) != 1 {
+		t.Errorf("expected a render-blocking script and an image without dimensions, got %+v", perf)
+	}
+	if want := []string{server.URL + "/logo.png"}; fmt.Sprint(perf.Uncached) != fmt.Sprint(want) {
+		t.Errorf("expected uncached %v, got %v", want, perf.Uncached)
+	}
+}
+
+func TestAnalyzeHTML_ExtractsStructuredData(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+		<script type="application/ld+json">{"@type": "Organization", "name": "Acme", "url": "https://example.com/"}</script>
+		<script type="application/ld+json">{"@type": </script>
+	</head></html>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	analyzer := &Analyzer{LinkChecker: &mockLinkChecker{brokenLinks: make(map[string]bool)}}
+
+	data := analyzer.AnalyzeHTML(doc, "https://example.com/").StructuredData
+	if data == nil || len(data.Entities) != 1 || data.Entities[0].Type != "Organization" || len(data.Entities[0].Issues) != 0 {
+		t.Fatalf("expected a valid Organization, got %+v", data)
+	}
+	if len(data.Errors) != 1 {
+		t.Errorf("expected the invalid block to be reported, got %v", data.Errors)
+	}
+}
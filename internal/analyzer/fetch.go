@@ -0,0 +1,149 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+	"web-analyzer/models"
+)
+
+const (
+	// maxRedirects is the hard limit of redirects followed before giving up.
+	maxRedirects = 10
+	// defaultRedirectThreshold is the chain length above which a result is
+	// flagged with LongRedirectChain.
+	defaultRedirectThreshold = 3
+)
+
+var (
+	// ErrRedirectLoop is returned when a redirect points to a URL already
+	// visited in the chain.
+	ErrRedirectLoop = errors.New("redirect loop detected")
+	// ErrTooManyRedirects is returned when the chain exceeds maxRedirects.
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// fetchResult holds the final response of a fetch together with the redirect
+// chain that led to it.
+type fetchResult struct {
+	Response  *http.Response
+	FinalURL  string
+	Chain     []models.RedirectHop
+	Loop      bool
+	Downgrade bool
+}
+
+// fetchPage fetches rawURL following redirects manually so that every hop is
+// recorded. The caller must close the returned response body.
+func fetchPage(ctx context.Context, client *http.Client, rawURL string) (*fetchResult, error) {
+	noFollow := *client
+	noFollow.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	result := &fetchResult{}
+	visited := make(map[string]bool)
+	current := rawURL
+
+	for {
+		if visited[current] {
+			result.Loop = true
+			return result, ErrRedirectLoop
+		}
+		if len(result.Chain) > maxRedirects {
+			return result, ErrTooManyRedirects
+		}
+		visited[current] = true
+
+		start := time.Now()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, current, nil)
+		if err != nil {
+			return result, err
+		}
+		resp, err := noFollow.Do(req)
+		if err != nil {
+			return result, err
+		}
+		result.Chain = append(result.Chain, models.RedirectHop{
+			URL:        current,
+			StatusCode: resp.StatusCode,
+			LatencyMs:  time.Since(start).Milliseconds(),
+		})
+
+		location := resp.Header.Get("Location")
+		if !isRedirect(resp.StatusCode) || location == "" {
+			result.Response = resp
+			result.FinalURL = current
+			return result, nil
+		}
+		resp.Body.Close()
+
+		next, err := resolveURL(current, location)
+		if err != nil {
+			return result, fmt.Errorf("invalid redirect location %q: %w", location, err)
+		}
+		if isDowngrade(current, next) {
+			result.Downgrade = true
+		}
+		current = next
+	}
+}
+
+// Redirects returns the number of redirects in the chain, excluding the final
+// response.
+func (f *fetchResult) Redirects() int {
+	if len(f.Chain) == 0 {
+		return 0
+	}
+	return len(f.Chain) - 1
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+func isDowngrade(from, to string) bool {
+	f, err := url.Parse(from)
+	if err != nil {
+		return false
+	}
+	t, err := url.Parse(to)
+	if err != nil {
+		return false
+	}
+	return f.Scheme == "https" && t.Scheme == "http"
+}
+
+// resolveURL resolves ref against base and returns the absolute URL.
+func resolveURL(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/models"
+)
+
+func TestFetchPage_RecordsRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/moved", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new/", http.StatusFound)
+	})
+	mux.HandleFunc("/new/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html></html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetched, err := fetchPage(context.Background(), server.Client(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer fetched.Response.Body.Close()
+
+	if fetched.FinalURL != server.URL+"/new/" {
+		t.Errorf("expected final URL %s/new/, got %s", server.URL, fetched.FinalURL)
+	}
+	if len(fetched.Chain) != 3 {
+		t.Fatalf("expected 3 hops, got %d", len(fetched.Chain))
+	}
+	if fetched.Chain[0].StatusCode != http.StatusMovedPermanently || fetched.Chain[1].StatusCode != http.StatusFound {
+		t.Errorf("unexpected hop statuses: %+v", fetched.Chain)
+	}
+	if fetched.Redirects() != 2 {
+		t.Errorf("expected 2 redirects, got %d", fetched.Redirects())
+	}
+	if fetched.Downgrade {
+		t.Error("expected no downgrade flag for a plain HTTP chain")
+	}
+}
+
+func TestFetchPage_DetectsDowngrade(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html></html>"))
+	}))
+	defer plain.Close()
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/insecure", http.StatusMovedPermanently)
+	}))
+	defer secure.Close()
+
+	fetched, err := fetchPage(context.Background(), secure.Client(), secure.URL+"/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer fetched.Response.Body.Close()
+
+	if !fetched.Downgrade {
+		t.Error("expected downgrade flag for an HTTPS to HTTP redirect")
+	}
+	if fetched.FinalURL != plain.URL+"/insecure" {
+		t.Errorf("expected final URL %s/insecure, got %s", plain.URL, fetched.FinalURL)
+	}
+}
+
+func TestFetchPage_DetectsLoop(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusFound)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/a", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetched, err := fetchPage(context.Background(), server.Client(), server.URL+"/a")
+	if !errors.Is(err, ErrRedirectLoop) {
+		t.Fatalf("expected redirect loop error, got %v", err)
+	}
+	if !fetched.Loop {
+		t.Error("expected loop flag to be set")
+	}
+}
+
+func TestAnalyzePage_ResolvesLinksAgainstFinalURL(t *testing.T) {
+	mockLinkChecker := &mockLinkChecker{brokenLinks: make(map[string]bool)}
+	mockAnalysis := &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)}
+
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:           &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker:       mockLinkChecker,
+			Analysis:          mockAnalysis,
+			RedirectThreshold: 1,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/step", http.StatusFound)
+	})
+	mux.HandleFunc("/step", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/", http.StatusFound)
+	})
+	mux.HandleFunc("/docs/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="page">Page</a></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockLinkChecker.brokenLinks[server.URL+"/docs/page"] = true
+
+	service.AnalyzePage(context.Background(), server.URL+"/")
+
+	result, exists := mockAnalysis.GetAnalysis(server.URL + "/")
+	if !exists {
+		t.Fatal("expected analysis result")
+	}
+	if result.BrokenLinks != 1 {
+		t.Errorf("expected link to resolve against final URL, got %d broken links", result.BrokenLinks)
+	}
+	if result.FinalURL != server.URL+"/docs/" {
+		t.Errorf("unexpected final URL %s", result.FinalURL)
+	}
+	if !result.LongRedirectChain {
+		t.Error("expected long redirect chain flag")
+	}
+}
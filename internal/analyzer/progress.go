@@ -0,0 +1,106 @@
+package analyzer
+
+import (
+	"context"
+
+	"web-analyzer/internal/logging"
+	"web-analyzer/models"
+)
+
+// Progress event types reported while a page is analysed.
+const (
+	EventFetchStarted  = "fetch_started"
+	EventFetchFinished = "fetch_finished"
+	EventParseDone     = "parse_done"
+	EventLinkChecked   = "link_checked"
+	// EventResourceChecked carries the checked resource in Link.
+	EventResourceChecked = "resource_checked"
+	EventCompleted       = "completed"
+	EventFailed          = "failed"
+)
+
+// ProgressEvent describes a step of an analysis.
+type ProgressEvent struct {
+	Type       string                 `json:"type"`
+	URL        string                 `json:"url,omitempty"`
+	StatusCode int                    `json:"status_code,omitempty"`
+	Link       string                 `json:"link,omitempty"`
+	Broken     bool                   `json:"broken,omitempty"`
+	Checked    int                    `json:"checked,omitempty"`
+	Total      int                    `json:"total,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Result     *models.AnalysisResult `json:"result,omitempty"`
+}
+
+// ProgressFunc receives progress events of an analysis.
+type ProgressFunc func(ProgressEvent)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose analyses report progress to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+func reportProgress(ctx context.Context, event ProgressEvent) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		fn(event)
+	}
+}
+
+// ResultFunc receives the result an analysis stored for its URL, and the
+// snapshot of the URL before it, if any.
+type ResultFunc func(result models.AnalysisResult, previous *models.Snapshot)
+
+type resultKey struct{}
+
+// WithResult returns a context whose analyses report their result to fn.
+func WithResult(ctx context.Context, fn ResultFunc) context.Context {
+	return context.WithValue(ctx, resultKey{}, fn)
+}
+
+// ReportResult passes the result of an analysis to the ResultFunc carried by
+// ctx. AnalyzerService implementations call it with the result they store, so
+// that callers learn their own result even when the URL is analysed again
+// concurrently.
+func ReportResult(ctx context.Context, result models.AnalysisResult, previous *models.Snapshot) {
+	if fn, ok := ctx.Value(resultKey{}).(ResultFunc); ok {
+		fn(result, previous)
+	}
+}
+
+type jobIDKey struct{}
+
+// WithJobID returns a context whose analyses record id as the job that ran
+// them.
+func WithJobID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, jobIDKey{}, id)
+}
+
+func jobIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(jobIDKey{}).(string)
+	return id
+}
+
+type checkLimitKey struct{}
+
+// WithCheckLimit returns a context whose analyses check at most n links and
+// resources. The rest are reported as not broken.
+func WithCheckLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, checkLimitKey{}, n)
+}
+
+// limitChecks truncates urls to the checks left of the limit carried by ctx,
+// given that done checks were already made.
+func limitChecks(ctx context.Context, urls []string, done int) []string {
+	limit, ok := ctx.Value(checkLimitKey{}).(int)
+	if !ok {
+		return urls
+	}
+	left := max(limit-done, 0)
+	if len(urls) > left {
+		logging.FromContext(ctx).Warn("Check limit reached", "limit", limit, "skipped", len(urls)-left)
+		return urls[:left]
+	}
+	return urls
+}
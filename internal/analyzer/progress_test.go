@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestAnalyzeHTMLContext_ReportsLinkProgress(t *testing.T) {
+	a := &Analyzer{LinkChecker: &mockLinkChecker{brokenLinks: map[string]bool{"https://example.com/b": true}}}
+	doc, _ := html.Parse(strings.NewReader(`<a href="/a">A</a><a href="/b">B</a><a href="/a">A again</a>`))
+
+	var events []ProgressEvent
+	ctx := WithProgress(context.Background(), func(e ProgressEvent) { events = append(events, e) })
+	a.AnalyzeHTMLContext(ctx, doc, "https://example.com/")
+
+	if len(events) != 2 {
+		t.Fatalf("expected one event per unique link, got %d", len(events))
+	}
+	last := events[1]
+	if last.Type != EventLinkChecked || last.Checked != 2 || last.Total != 2 || !last.Broken {
+		t.Errorf("unexpected event: %+v", last)
+	}
+}
+
+func TestAnalyzeHTMLContext_LimitsChecks(t *testing.T) {
+	a := &Analyzer{LinkChecker: &mockLinkChecker{brokenLinks: map[string]bool{"https://example.com/b": true}}}
+	doc, _ := html.Parse(strings.NewReader(`<img src="/logo.png"><a href="/a">A</a><a href="/b">B</a>`))
+
+	var checked []string
+	ctx := WithProgress(context.Background(), func(e ProgressEvent) { checked = append(checked, e.Link) })
+	result := a.AnalyzeHTMLContext(WithCheckLimit(ctx, 1), doc, "https://example.com/")
+
+	if len(checked) != 1 || checked[0] != "https://example.com/a" {
+		t.Errorf("expected only the first link to be checked, got %v", checked)
+	}
+	if result.BrokenLinks != 0 || result.Resources.Broken != 0 {
+		t.Errorf("expected unchecked URLs not to be broken, got %d links and %d resources", result.BrokenLinks, result.Resources.Broken)
+	}
+}
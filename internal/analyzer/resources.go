@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	neturl "net/url"
+	"regexp"
+	"strings"
+
+	"web-analyzer/internal/htmlutil"
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/publicsuffix"
+)
+
+// cssURL matches the url() references of a style sheet.
+var cssURL = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^)\s]*))\s*\)`)
+
+// resourceCollector builds the resource inventory of a document while it is
+// traversed.
+type resourceCollector struct {
+	base      *neturl.URL
+	resources models.Resources
+	seen      map[models.Resource]bool
+}
+
+func newResourceCollector(base *neturl.URL) *resourceCollector {
+	return &resourceCollector{base: base, seen: make(map[models.Resource]bool)}
+}
+
+// visit records the resources referenced by an element.
+func (c *resourceCollector) visit(n *html.Node) {
+	if style, ok := htmlutil.Attr(n, "style"); ok {
+		c.resources.StyleAttributes++
+		c.addCSS(style)
+	}
+	switch n.Data {
+	case "script":
+		if src, ok := htmlutil.Attr(n, "src"); ok {
+			c.add(models.ResourceScript, src)
+		} else if isExecutable(n) {
+			c.resources.InlineScripts++
+		}
+	case "link":
+		rel, _ := htmlutil.Attr(n, "rel")
+		if href, ok := htmlutil.Attr(n, "href"); ok && htmlutil.HasToken(rel, "stylesheet") {
+			c.add(models.ResourceStylesheet, href)
+		}
+	case "style":
+		c.resources.InlineStyles++
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.TextNode {
+				c.addCSS(child.Data)
+			}
+		}
+	case "img":
+		if src, ok := htmlutil.Attr(n, "src"); ok {
+			c.add(models.ResourceImage, src)
+		}
+		c.addSrcset(models.ResourceImage, n)
+	case "iframe":
+		if src, ok := htmlutil.Attr(n, "src"); ok {
+			c.add(models.ResourceIframe, src)
+		}
+	case "video", "audio":
+		if src, ok := htmlutil.Attr(n, "src"); ok {
+			c.add(models.ResourceMedia, src)
+		}
+		if poster, ok := htmlutil.Attr(n, "poster"); ok {
+			c.add(models.ResourceImage, poster)
+		}
+	case "source":
+		// Sources of a <picture> are images, those of <video> and <audio> media.
+		typ := models.ResourceMedia
+		if n.Parent != nil && n.Parent.Data == "picture" {
+			typ = models.ResourceImage
+		}
+		if src, ok := htmlutil.Attr(n, "src"); ok {
+			c.add(typ, src)
+		}
+		c.addSrcset(typ, n)
+	}
+}
+
+func (c *resourceCollector) addSrcset(typ string, n *html.Node) {
+	srcset, ok := htmlutil.Attr(n, "srcset")
+	if !ok {
+		return
+	}
+	for _, candidate := range strings.Split(srcset, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 {
+			c.add(typ, fields[0])
+		}
+	}
+}
+
+func (c *resourceCollector) addCSS(css string) {
+	for _, m := range cssURL.FindAllStringSubmatch(css, -1) {
+		c.add(models.ResourceCSS, m[1]+m[2]+m[3])
+	}
+}
+
+// add records a reference unless it is empty, a fragment or not fetched over
+// HTTP, such as data: URIs.
+func (c *resourceCollector) add(typ, ref string) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") {
+		return
+	}
+	u, err := neturl.Parse(ref)
+	if err != nil {
+		return
+	}
+	abs := c.base.ResolveReference(u)
+	if abs.Scheme != "" && abs.Scheme != "http" && abs.Scheme != "https" {
+		return
+	}
+	resource := models.Resource{URL: abs.String(), Type: typ, ThirdParty: isThirdParty(c.base, abs)}
+	if c.seen[resource] {
+		return
+	}
+	c.seen[resource] = true
+	c.resources.Items = append(c.resources.Items, resource)
+	if resource.ThirdParty {
+		c.resources.ThirdParty++
+	} else {
+		c.resources.FirstParty++
+	}
+}
+
+// isThirdParty reports whether u is outside the registrable domain of the page,
+// so that e.g. cdn.example.com is first-party to www.example.com.
+func isThirdParty(page, u *neturl.URL) bool {
+	if u.Host == "" || u.Hostname() == page.Hostname() {
+		return false
+	}
+	pageDomain, err := publicsuffix.EffectiveTLDPlusOne(page.Hostname())
+	if err != nil {
+		return true
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(u.Hostname())
+	return err != nil || domain != pageDomain
+}
+
+// isExecutable reports whether an inline script is JavaScript rather than data
+// such as JSON-LD.
+func isExecutable(n *html.Node) bool {
+	typ, _ := htmlutil.Attr(n, "type")
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case "", "module", "text/javascript", "application/javascript":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+func TestAnalyzeHTML_Resources(t *testing.T) {
+	page := `<!DOCTYPE html>
+		<html><head>
+			<script src="/app.js"></script>
+			<script>console.log("inline")</script>
+			<script type="application/ld+json">{}</script>
+			<link rel="stylesheet" href="https://cdn.example.com/site.css">
+			<link rel="canonical" href="https://www.example.com/">
+			<style>body { background: url("/bg.png") } .icon { background: url(data:image/png;base64,AAAA) }</style>
+		</head><body>
+			<img src="/logo.png" srcset="/logo-2x.png 2x, /logo.png 1x">
+			<picture><source srcset="https://images.other.com/hero.webp"></picture>
+			<iframe src="https://www.youtube.com/embed/x"></iframe>
+			<video poster="/poster.jpg"><source src="/movie.mp4"></video>
+			<div style="background-image: url('https://fonts.other.com/bg.png')"></div>
+			<a href="https://www.example.com/app.js">Same as a script</a>
+		</body></html>`
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checker := &mockLinkChecker{brokenLinks: map[string]bool{"https://www.example.com/logo-2x.png": true}}
+	var events []ProgressEvent
+	ctx := WithProgress(context.Background(), func(e ProgressEvent) { events = append(events, e) })
+
+	result := (&Analyzer{LinkChecker: checker}).AnalyzeHTMLContext(ctx, doc, "https://www.example.com/")
+
+	want := []models.Resource{
+		{URL: "https://www.example.com/app.js", Type: models.ResourceScript},
+		{URL: "https://cdn.example.com/site.css", Type: models.ResourceStylesheet},
+		{URL: "https://www.example.com/bg.png", Type: models.ResourceCSS},
+		{URL: "https://www.example.com/logo.png", Type: models.ResourceImage},
+		{URL: "https://www.example.com/logo-2x.png", Type: models.ResourceImage, Broken: true},
+		{URL: "https://images.other.com/hero.webp", Type: models.ResourceImage, ThirdParty: true},
+		{URL: "https://www.youtube.com/embed/x", Type: models.ResourceIframe, ThirdParty: true},
+		{URL: "https://www.example.com/poster.jpg", Type: models.ResourceImage},
+		{URL: "https://www.example.com/movie.mp4", Type: models.ResourceMedia},
+		{URL: "https://fonts.other.com/bg.png", Type: models.ResourceCSS, ThirdParty: true},
+	}
+	res := result.Resources
+	if res == nil {
+		t.Fatal("expected a resource inventory")
+	}
+	if !reflect.DeepEqual(res.Items, want) {
+		t.Errorf("expected resources\n%+v\ngot\n%+v", want, res.Items)
+	}
+	if res.FirstParty != 7 || res.ThirdParty != 3 || res.Broken != 1 {
+		t.Errorf("unexpected counts: %+v", res)
+	}
+	if res.InlineScripts != 1 || res.InlineStyles != 1 || res.StyleAttributes != 1 {
+		t.Errorf("unexpected inline counts: %+v", res)
+	}
+
+	resourceChecks := 0
+	for _, e := range events {
+		if e.Type == EventResourceChecked {
+			resourceChecks++
+			if e.Link == "https://www.example.com/app.js" {
+				t.Error("expected the script already checked as a link not to be checked again")
+			}
+		}
+	}
+	if resourceChecks != len(want)-1 {
+		t.Errorf("expected %d resource checks, got %d", len(want)-1, resourceChecks)
+	}
+}
+
+func TestAnalyzeHTML_ResourcesWithoutBaseURL(t *testing.T) {
+	doc, _ := html.Parse(strings.NewReader(`<script src="app.js"></script><img src="https://cdn.other.com/a.png">`))
+	checker := &mockLinkChecker{brokenLinks: map[string]bool{}}
+
+	res := (&Analyzer{LinkChecker: checker}).AnalyzeHTML(doc, "").Resources
+	want := []models.Resource{
+		{URL: "/app.js", Type: models.ResourceScript},
+		{URL: "https://cdn.other.com/a.png", Type: models.ResourceImage, ThirdParty: true},
+	}
+	if !reflect.DeepEqual(res.Items, want) {
+		t.Errorf("expected %+v, got %+v", want, res.Items)
+	}
+}
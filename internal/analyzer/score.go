@@ -0,0 +1,29 @@
+package analyzer
+
+import "web-analyzer/models"
+
+// Score rates a completed analysis from 0 to 100. Points are deducted for
+// missing or duplicated structure, broken links and redirect problems.
+func Score(result models.AnalysisResult) int {
+	score := 100
+	if result.Title == "" {
+		score -= 10
+	}
+	switch h1 := result.Headings["h1"]; {
+	case h1 == 0:
+		score -= 10
+	case h1 > 1:
+		score -= 5
+	}
+	if result.HTMLVersion == "No DOCTYPE found" {
+		score -= 5
+	}
+	score -= min(result.BrokenLinks*5, 40)
+	if result.LongRedirectChain {
+		score -= 5
+	}
+	if result.HTTPSDowngrade {
+		score -= 10
+	}
+	return max(score, 0)
+}
@@ -0,0 +1,38 @@
+package analyzer
+
+import (
+	"testing"
+	"web-analyzer/models"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		result models.AnalysisResult
+		want   int
+	}{
+		{
+			name:   "Clean page",
+			result: models.AnalysisResult{Title: "Home", HTMLVersion: "HTML5", Headings: map[string]int{"h1": 1}},
+			want:   100,
+		},
+		{
+			name:   "Missing title and h1",
+			result: models.AnalysisResult{HTMLVersion: "HTML5", Headings: map[string]int{}},
+			want:   80,
+		},
+		{
+			name:   "Broken links are capped",
+			result: models.AnalysisResult{Title: "Home", HTMLVersion: "HTML5", Headings: map[string]int{"h1": 1}, BrokenLinks: 20},
+			want:   60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(tt.result); got != tt.want {
+				t.Errorf("Score() = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web-analyzer/models"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAnalyzePage_RecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><a href="/a">A</a><a href="/b">B</a></body></html>`))
+	}))
+	defer server.Close()
+
+	service := DefaultAnalyzerService{
+		Analyzer: &Analyzer{
+			Storage:     &mockStorage{submittedUrls: make(map[string]bool)},
+			LinkChecker: &mockLinkChecker{brokenLinks: map[string]bool{server.URL + "/b": true}},
+			Analysis:    &mockAnalysis{analysisResults: make(map[string]models.AnalysisResult)},
+		},
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "analysis")
+	if err := service.AnalyzePage(ctx, server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parent.End()
+
+	counts := map[string]int{}
+	for _, span := range recorder.Ended() {
+		counts[span.Name()]++
+		if span.Name() != "analysis" && span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %q is not a child of the analysis span", span.Name())
+		}
+	}
+	if counts["fetch"] != 1 || counts["parse"] != 1 || counts["link_check"] != 2 {
+		t.Errorf("unexpected spans: %v", counts)
+	}
+}
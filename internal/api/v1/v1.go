@@ -0,0 +1,597 @@
+// Package v1 defines the resources of the versioned /v1 API. They use
+// snake_case fields, typed enums and booleans, and are converted from the
+// models shared with the original, unversioned endpoints.
+package v1
+
+import (
+	"strings"
+	"time"
+
+	"web-analyzer/internal/jobs"
+	"web-analyzer/models"
+)
+
+// AnalysisStatus is the state of an analysis.
+type AnalysisStatus string
+
+const (
+	AnalysisInProgress AnalysisStatus = "in_progress"
+	AnalysisCompleted  AnalysisStatus = "completed"
+	AnalysisFailed     AnalysisStatus = "failed"
+)
+
+// Values implements openapi.Enum.
+func (AnalysisStatus) Values() []string {
+	return []string{string(AnalysisInProgress), string(AnalysisCompleted), string(AnalysisFailed)}
+}
+
+// JobStatus is the lifecycle state of a job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// Values implements openapi.Enum.
+func (JobStatus) Values() []string {
+	return []string{string(JobQueued), string(JobRunning), string(JobCompleted), string(JobFailed)}
+}
+
+// HeaderStatus rates a security header.
+type HeaderStatus string
+
+const (
+	HeaderGood    HeaderStatus = "good"
+	HeaderWeak    HeaderStatus = "weak"
+	HeaderMissing HeaderStatus = "missing"
+)
+
+// Values implements openapi.Enum.
+func (HeaderStatus) Values() []string {
+	return []string{string(HeaderGood), string(HeaderWeak), string(HeaderMissing)}
+}
+
+// ResourceType is the kind of a subresource.
+type ResourceType string
+
+const (
+	ResourceScript     ResourceType = "script"
+	ResourceStylesheet ResourceType = "stylesheet"
+	ResourceImage      ResourceType = "image"
+	ResourceIframe     ResourceType = "iframe"
+	ResourceMedia      ResourceType = "media"
+	ResourceCSS        ResourceType = "css"
+)
+
+// Values implements openapi.Enum.
+func (ResourceType) Values() []string {
+	return []string{
+		string(ResourceScript), string(ResourceStylesheet), string(ResourceImage),
+		string(ResourceIframe), string(ResourceMedia), string(ResourceCSS),
+	}
+}
+
+// StructuredDataFormat is the syntax a structured data entity is written in.
+type StructuredDataFormat string
+
+const (
+	FormatJSONLD    StructuredDataFormat = "json_ld"
+	FormatMicrodata StructuredDataFormat = "microdata"
+	FormatRDFa      StructuredDataFormat = "rdfa"
+)
+
+// Values implements openapi.Enum.
+func (StructuredDataFormat) Values() []string {
+	return []string{string(FormatJSONLD), string(FormatMicrodata), string(FormatRDFa)}
+}
+
+// AnalyzeRequest submits a URL for analysis.
+type AnalyzeRequest struct {
+	URL string `json:"url"`
+	// CallbackURL receives a signed webhook when the analysis finishes.
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// Job is an analysis queued for a URL.
+type Job struct {
+	ID         string     `json:"id"`
+	Workspace  string     `json:"workspace"`
+	URL        string     `json:"url"`
+	BatchID    string     `json:"batch_id,omitempty"`
+	Status     JobStatus  `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Headings counts the headings of a page by level.
+type Headings struct {
+	H1 int `json:"h1"`
+	H2 int `json:"h2"`
+	H3 int `json:"h3"`
+	H4 int `json:"h4"`
+	H5 int `json:"h5"`
+	H6 int `json:"h6"`
+}
+
+// LinkSummary counts the links of a page.
+type LinkSummary struct {
+	Internal   int      `json:"internal"`
+	External   int      `json:"external"`
+	Broken     int      `json:"broken"`
+	BrokenURLs []string `json:"broken_urls"`
+	URLs       []string `json:"urls"`
+}
+
+// RedirectHop is a single response received while fetching a page.
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+	LatencyMs  int64  `json:"latency_ms"`
+}
+
+// Redirects describes how the fetched URL redirected to the final one.
+type Redirects struct {
+	FinalURL string        `json:"final_url"`
+	Chain    []RedirectHop `json:"chain"`
+	Loop     bool          `json:"loop"`
+	// HTTPSDowngrade is set when a hop redirects from HTTPS to HTTP.
+	HTTPSDowngrade bool `json:"https_downgrade"`
+	// LongChain is set when the chain has more hops than allowed.
+	LongChain bool `json:"long_chain"`
+}
+
+// Technology is a product, framework or service a page is built with.
+type Technology struct {
+	Name     string `json:"name"`
+	Category string `json:"category,omitempty"`
+	Version  string `json:"version,omitempty"`
+}
+
+// SecurityHeader rates a security response header.
+type SecurityHeader struct {
+	Name   string       `json:"name"`
+	Value  string       `json:"value,omitempty"`
+	Status HeaderStatus `json:"status"`
+	Issues []string     `json:"issues"`
+}
+
+// Cookie lists the flags of a cookie set by the page.
+type Cookie struct {
+	Name     string `json:"name"`
+	Secure   bool   `json:"secure"`
+	HTTPOnly bool   `json:"http_only"`
+	// SameSite is "lax", "strict", "none" or empty when not set.
+	SameSite string   `json:"same_site,omitempty"`
+	Issues   []string `json:"issues"`
+}
+
+// TLS describes the connection a page was fetched over.
+type TLS struct {
+	Version            string    `json:"version"`
+	CipherSuite        string    `json:"cipher_suite"`
+	CertificateSubject string    `json:"certificate_subject,omitempty"`
+	CertificateIssuer  string    `json:"certificate_issuer,omitempty"`
+	CertificateExpiry  time.Time `json:"certificate_expiry"`
+	DaysUntilExpiry    int       `json:"days_until_expiry"`
+	Issues             []string  `json:"issues"`
+}
+
+// Security audits the headers, cookies, subresources and connection of a page.
+type Security struct {
+	Headers []SecurityHeader `json:"headers"`
+	Cookies []Cookie         `json:"cookies"`
+	// MixedContent lists the http:// subresources of an https page.
+	MixedContent []string `json:"mixed_content"`
+	TLS          *TLS     `json:"tls,omitempty"`
+}
+
+// Resource is a subresource referenced by a page.
+type Resource struct {
+	URL  string       `json:"url"`
+	Type ResourceType `json:"type"`
+	// ThirdParty is set for resources outside the page's registrable domain.
+	ThirdParty bool `json:"third_party"`
+	Broken     bool `json:"broken"`
+}
+
+// Resources inventories the subresources and inline code of a page.
+type Resources struct {
+	Items           []Resource `json:"items"`
+	FirstParty      int        `json:"first_party"`
+	ThirdParty      int        `json:"third_party"`
+	Broken          int        `json:"broken"`
+	InlineScripts   int        `json:"inline_scripts"`
+	InlineStyles    int        `json:"inline_styles"`
+	StyleAttributes int        `json:"style_attributes"`
+}
+
+// Performance estimates the weight of a page and lists what slows its loading.
+type Performance struct {
+	DocumentBytes int64 `json:"document_bytes"`
+	TotalBytes    int64 `json:"total_bytes"`
+	// BytesByType is keyed by "document" and the resource types.
+	BytesByType map[string]int64 `json:"bytes_by_type"`
+	Requests    int              `json:"requests"`
+	// Fetched is set when the subresources were fetched. The sizes, Uncompressed
+	// and Uncached only cover the document otherwise.
+	Fetched                  bool     `json:"fetched"`
+	Truncated                bool     `json:"truncated"`
+	RenderBlocking           []string `json:"render_blocking"`
+	ImagesWithoutDimensions  []string `json:"images_without_dimensions"`
+	ImagesWithoutLazyLoading []string `json:"images_without_lazy_loading"`
+	Uncompressed             []string `json:"uncompressed"`
+	Uncached                 []string `json:"uncached"`
+}
+
+// Entity is a schema.org entity described by a page.
+type Entity struct {
+	Format StructuredDataFormat `json:"format"`
+	Type   string               `json:"type"`
+	ID     string               `json:"id,omitempty"`
+	// Properties maps property names to their values. Nested entities are
+	// represented by their name, identifier or type.
+	Properties map[string][]string `json:"properties"`
+	// Issues lists the required properties missing from the entity.
+	Issues []string `json:"issues"`
+}
+
+// StructuredData lists the schema.org entities of a page.
+type StructuredData struct {
+	Entities []Entity `json:"entities"`
+	Errors   []string `json:"errors"`
+}
+
+// Analysis is the result of analysing a page.
+type Analysis struct {
+	URL    string         `json:"url"`
+	Status AnalysisStatus `json:"status"`
+	// Error explains why a failed analysis failed.
+	Error        string      `json:"error,omitempty"`
+	HTMLVersion  string      `json:"html_version"`
+	Title        string      `json:"title"`
+	Headings     Headings    `json:"headings"`
+	Links        LinkSummary `json:"links"`
+	HasLoginForm bool        `json:"has_login_form"`
+	Score        int         `json:"score"`
+	Redirects    *Redirects  `json:"redirects,omitempty"`
+	// Technologies lists the detected tech stack, ordered by name.
+	Technologies []Technology `json:"technologies"`
+	// Security is missing for uploaded documents and failed fetches.
+	Security       *Security       `json:"security,omitempty"`
+	Resources      *Resources      `json:"resources,omitempty"`
+	Performance    *Performance    `json:"performance,omitempty"`
+	StructuredData *StructuredData `json:"structured_data,omitempty"`
+	AnalyzedAt     *time.Time      `json:"analyzed_at,omitempty"`
+}
+
+// Snapshot is a completed analysis kept in the history of a URL.
+type Snapshot struct {
+	Version  int      `json:"version"`
+	Analysis Analysis `json:"analysis"`
+}
+
+// History lists the snapshots of a URL, oldest first.
+type History struct {
+	URL       string     `json:"url"`
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// FieldChange describes a field whose value differs between two snapshots.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// Diff lists what changed between two snapshots of a URL.
+type Diff struct {
+	URL              string        `json:"url"`
+	FromVersion      int           `json:"from_version"`
+	ToVersion        int           `json:"to_version"`
+	Changes          []FieldChange `json:"changes"`
+	LinksAdded       []string      `json:"links_added"`
+	LinksRemoved     []string      `json:"links_removed"`
+	NewlyBrokenLinks []string      `json:"newly_broken_links"`
+	FixedLinks       []string      `json:"fixed_links"`
+}
+
+// URL summarises the submissions of a URL and its latest analysis.
+type URL struct {
+	URL              string         `json:"url"`
+	SubmitCount      int            `json:"submit_count"`
+	FirstSubmittedAt time.Time      `json:"first_submitted_at"`
+	LastSubmittedAt  time.Time      `json:"last_submitted_at"`
+	LastJobID        string         `json:"last_job_id,omitempty"`
+	LastStatus       AnalysisStatus `json:"last_status,omitempty"`
+	LastScore        int            `json:"last_score"`
+	LastTitle        string         `json:"last_title,omitempty"`
+	LastError        string         `json:"last_error,omitempty"`
+	LastAnalyzedAt   *time.Time     `json:"last_analyzed_at,omitempty"`
+}
+
+// URLPage is a page of URLs.
+type URLPage struct {
+	URLs []URL `json:"urls"`
+	// Total is the number of URLs matching the filters, across all pages.
+	Total int `json:"total"`
+	// NextCursor fetches the following page. It is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ParseAnalysisStatus converts the status of a models.AnalysisResult.
+func ParseAnalysisStatus(s string) AnalysisStatus {
+	switch s {
+	case "Completed":
+		return AnalysisCompleted
+	case "Failed":
+		return AnalysisFailed
+	case "":
+		return ""
+	}
+	return AnalysisInProgress
+}
+
+// Legacy returns the status as stored in models.AnalysisResult.
+func (s AnalysisStatus) Legacy() string {
+	switch s {
+	case AnalysisInProgress:
+		return "In progress"
+	case AnalysisCompleted:
+		return "Completed"
+	case AnalysisFailed:
+		return "Failed"
+	}
+	return string(s)
+}
+
+// NewJob converts a job.
+func NewJob(j jobs.Job) Job {
+	status := JobQueued
+	switch j.Status {
+	case jobs.StatusRunning:
+		status = JobRunning
+	case jobs.StatusCompleted:
+		status = JobCompleted
+	case jobs.StatusFailed:
+		status = JobFailed
+	}
+	return Job{
+		ID:         j.ID,
+		Workspace:  j.Workspace,
+		URL:        j.URL,
+		BatchID:    j.BatchID,
+		Status:     status,
+		Error:      j.Error,
+		CreatedAt:  j.CreatedAt,
+		StartedAt:  j.StartedAt,
+		FinishedAt: j.FinishedAt,
+	}
+}
+
+// NewAnalysis converts an analysis result.
+func NewAnalysis(r models.AnalysisResult) Analysis {
+	a := Analysis{
+		URL:         r.URL,
+		Status:      ParseAnalysisStatus(r.Status),
+		Error:       r.Message,
+		HTMLVersion: r.HTMLVersion,
+		Title:       r.Title,
+		Headings: Headings{
+			H1: r.Headings["h1"],
+			H2: r.Headings["h2"],
+			H3: r.Headings["h3"],
+			H4: r.Headings["h4"],
+			H5: r.Headings["h5"],
+			H6: r.Headings["h6"],
+		},
+		Links: LinkSummary{
+			Internal:   r.InternalLinks,
+			External:   r.ExternalLinks,
+			Broken:     r.BrokenLinks,
+			BrokenURLs: nonNil(r.BrokenLinkURLs),
+			URLs:       nonNil(r.Links),
+		},
+		HasLoginForm: r.LoginForm == "Present",
+		Score:        r.Score,
+		Technologies: make([]Technology, 0, len(r.Technologies)),
+	}
+	for _, tech := range r.Technologies {
+		a.Technologies = append(a.Technologies, Technology{Name: tech.Name, Category: tech.Category, Version: tech.Version})
+	}
+	if r.Security != nil {
+		a.Security = newSecurity(*r.Security)
+	}
+	if r.Resources != nil {
+		a.Resources = newResources(*r.Resources)
+	}
+	if r.Performance != nil {
+		a.Performance = newPerformance(*r.Performance)
+	}
+	if r.StructuredData != nil {
+		a.StructuredData = newStructuredData(*r.StructuredData)
+	}
+	if !r.AnalyzedAt.IsZero() {
+		analyzedAt := r.AnalyzedAt
+		a.AnalyzedAt = &analyzedAt
+	}
+	if r.FinalURL != "" || len(r.RedirectChain) > 0 {
+		a.Redirects = &Redirects{
+			FinalURL:       r.FinalURL,
+			Chain:          make([]RedirectHop, 0, len(r.RedirectChain)),
+			Loop:           r.RedirectLoop,
+			HTTPSDowngrade: r.HTTPSDowngrade,
+			LongChain:      r.LongRedirectChain,
+		}
+		for _, hop := range r.RedirectChain {
+			a.Redirects.Chain = append(a.Redirects.Chain, RedirectHop{URL: hop.URL, StatusCode: hop.StatusCode, LatencyMs: hop.LatencyMs})
+		}
+	}
+	return a
+}
+
+func newSecurity(s models.Security) *Security {
+	sec := &Security{
+		Headers:      make([]SecurityHeader, 0, len(s.Headers)),
+		Cookies:      make([]Cookie, 0, len(s.Cookies)),
+		MixedContent: nonNil(s.MixedContent),
+	}
+	for _, h := range s.Headers {
+		sec.Headers = append(sec.Headers, SecurityHeader{
+			Name:   h.Name,
+			Value:  h.Value,
+			Status: HeaderStatus(strings.ToLower(h.Status)),
+			Issues: nonNil(h.Issues),
+		})
+	}
+	for _, c := range s.Cookies {
+		sec.Cookies = append(sec.Cookies, Cookie{
+			Name:     c.Name,
+			Secure:   c.Secure,
+			HTTPOnly: c.HttpOnly,
+			SameSite: strings.ToLower(c.SameSite),
+			Issues:   nonNil(c.Issues),
+		})
+	}
+	if s.TLS != nil {
+		sec.TLS = &TLS{
+			Version:            s.TLS.Version,
+			CipherSuite:        s.TLS.CipherSuite,
+			CertificateSubject: s.TLS.CertificateSubject,
+			CertificateIssuer:  s.TLS.CertificateIssuer,
+			CertificateExpiry:  s.TLS.CertificateExpiry,
+			DaysUntilExpiry:    s.TLS.DaysUntilExpiry,
+			Issues:             nonNil(s.TLS.Issues),
+		}
+	}
+	return sec
+}
+
+func newResources(r models.Resources) *Resources {
+	res := &Resources{
+		Items:           make([]Resource, 0, len(r.Items)),
+		FirstParty:      r.FirstParty,
+		ThirdParty:      r.ThirdParty,
+		Broken:          r.Broken,
+		InlineScripts:   r.InlineScripts,
+		InlineStyles:    r.InlineStyles,
+		StyleAttributes: r.StyleAttributes,
+	}
+	for _, item := range r.Items {
+		res.Items = append(res.Items, Resource{
+			URL:        item.URL,
+			Type:       ResourceType(strings.ToLower(item.Type)),
+			ThirdParty: item.ThirdParty,
+			Broken:     item.Broken,
+		})
+	}
+	return res
+}
+
+func newPerformance(p models.Performance) *Performance {
+	perf := &Performance{
+		DocumentBytes:            p.DocumentBytes,
+		TotalBytes:               p.TotalBytes,
+		BytesByType:              make(map[string]int64, len(p.BytesByType)),
+		Requests:                 p.Requests,
+		Fetched:                  p.Fetched,
+		Truncated:                p.Truncated,
+		RenderBlocking:           nonNil(p.RenderBlocking),
+		ImagesWithoutDimensions:  nonNil(p.ImagesWithoutDimensions),
+		ImagesWithoutLazyLoading: nonNil(p.ImagesWithoutLazyLoading),
+		Uncompressed:             nonNil(p.Uncompressed),
+		Uncached:                 nonNil(p.Uncached),
+	}
+	for typ, bytes := range p.BytesByType {
+		perf.BytesByType[strings.ToLower(typ)] = bytes
+	}
+	return perf
+}
+
+// structuredDataFormats maps the formats of models.Entity.
+var structuredDataFormats = map[string]StructuredDataFormat{
+	models.FormatJSONLD:    FormatJSONLD,
+	models.FormatMicrodata: FormatMicrodata,
+	models.FormatRDFa:      FormatRDFa,
+}
+
+func newStructuredData(d models.StructuredData) *StructuredData {
+	data := &StructuredData{
+		Entities: make([]Entity, 0, len(d.Entities)),
+		Errors:   nonNil(d.Errors),
+	}
+	for _, e := range d.Entities {
+		properties := e.Properties
+		if properties == nil {
+			properties = map[string][]string{}
+		}
+		data.Entities = append(data.Entities, Entity{
+			Format:     structuredDataFormats[e.Format],
+			Type:       e.Type,
+			ID:         e.ID,
+			Properties: properties,
+			Issues:     nonNil(e.Issues),
+		})
+	}
+	return data
+}
+
+// NewHistory converts the snapshots of a URL.
+func NewHistory(url string, snapshots []models.Snapshot) History {
+	h := History{URL: url, Snapshots: make([]Snapshot, 0, len(snapshots))}
+	for _, s := range snapshots {
+		h.Snapshots = append(h.Snapshots, Snapshot{Version: s.Version, Analysis: NewAnalysis(s.Result)})
+	}
+	return h
+}
+
+// NewDiff converts a snapshot diff.
+func NewDiff(d models.SnapshotDiff) Diff {
+	diff := Diff{
+		URL:              d.URL,
+		FromVersion:      d.FromVersion,
+		ToVersion:        d.ToVersion,
+		Changes:          make([]FieldChange, 0, len(d.Changes)),
+		LinksAdded:       nonNil(d.LinksAdded),
+		LinksRemoved:     nonNil(d.LinksRemoved),
+		NewlyBrokenLinks: nonNil(d.NewlyBrokenLinks),
+		FixedLinks:       nonNil(d.FixedLinks),
+	}
+	for _, c := range d.Changes {
+		diff.Changes = append(diff.Changes, FieldChange{Field: c.Field, Old: c.Old, New: c.New})
+	}
+	return diff
+}
+
+// NewURLPage converts a page of URL entries.
+func NewURLPage(entries []models.URLEntry, total int, nextCursor string) URLPage {
+	page := URLPage{URLs: make([]URL, 0, len(entries)), Total: total, NextCursor: nextCursor}
+	for _, e := range entries {
+		page.URLs = append(page.URLs, URL{
+			URL:              e.URL,
+			SubmitCount:      e.SubmitCount,
+			FirstSubmittedAt: e.FirstSubmittedAt,
+			LastSubmittedAt:  e.LastSubmittedAt,
+			LastJobID:        e.LastJobID,
+			LastStatus:       ParseAnalysisStatus(e.LastStatus),
+			LastScore:        e.LastScore,
+			LastTitle:        e.LastTitle,
+			LastError:        e.LastError,
+			LastAnalyzedAt:   e.LastAnalyzedAt,
+		})
+	}
+	return page
+}
+
+// nonNil returns s, or an empty slice when it is nil, so lists are encoded as
+// [] rather than null.
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"web-analyzer/internal/jobs"
+	"web-analyzer/models"
+)
+
+func TestNewAnalysis(t *testing.T) {
+	analyzedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	a := NewAnalysis(models.AnalysisResult{
+		URL:            "https://example.com",
+		Status:         "Completed",
+		HTMLVersion:    "HTML5",
+		Headings:       map[string]int{"h1": 1, "h3": 2},
+		InternalLinks:  3,
+		BrokenLinks:    1,
+		LoginForm:      "Present",
+		FinalURL:       "https://www.example.com/",
+		RedirectChain:  []models.RedirectHop{{URL: "https://example.com", StatusCode: 301}},
+		Resources:      &models.Resources{Items: []models.Resource{{URL: "https://cdn.other.com/bg.png", Type: models.ResourceCSS, ThirdParty: true}}},
+		Performance:    &models.Performance{BytesByType: map[string]int64{"Document": 2048}, Requests: 2},
+		StructuredData: &models.StructuredData{Entities: []models.Entity{{Format: models.FormatJSONLD, Type: "Product", Properties: map[string][]string{"name": {"Widget"}}}}},
+		AnalyzedAt:     analyzedAt,
+	})
+
+	if a.Status != AnalysisCompleted || !a.HasLoginForm || a.Headings.H1 != 1 || a.Headings.H3 != 2 {
+		t.Errorf("unexpected analysis: %+v", a)
+	}
+	if a.Redirects == nil || a.Redirects.FinalURL != "https://www.example.com/" || a.Redirects.Chain[0].StatusCode != 301 {
+		t.Errorf("unexpected redirects: %+v", a.Redirects)
+	}
+	if a.AnalyzedAt == nil || !a.AnalyzedAt.Equal(analyzedAt) {
+		t.Errorf("unexpected analyzed_at: %v", a.AnalyzedAt)
+	}
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"html_version":"HTML5"`, `"has_login_form":true`, `"broken_urls":[]`, `"technologies":[]`, `"status":"completed"`, `"type":"css","third_party":true`, `"bytes_by_type":{"document":2048}`, `"render_blocking":[]`, `"format":"json_ld","type":"Product"`} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected %s in %s", want, body)
+		}
+	}
+	if strings.Contains(string(body), " ") {
+		t.Errorf("expected no keys with spaces in %s", body)
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	if got := NewAnalysis(models.AnalysisResult{Status: "In progress", LoginForm: "Not Present"}); got.Status != AnalysisInProgress || got.HasLoginForm {
+		t.Errorf("unexpected analysis: %+v", got)
+	}
+	if got := NewJob(jobs.Job{Status: jobs.StatusRunning}).Status; got != JobRunning {
+		t.Errorf("job status = %q; want %q", got, JobRunning)
+	}
+	for _, s := range []AnalysisStatus{AnalysisInProgress, AnalysisCompleted, AnalysisFailed} {
+		if got := ParseAnalysisStatus(s.Legacy()); got != s {
+			t.Errorf("round trip of %q gave %q", s, got)
+		}
+	}
+}
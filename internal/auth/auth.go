@@ -0,0 +1,318 @@
+// Package auth authenticates API requests with API keys. Only the SHA-256 hash
+// of a key is stored; the key itself is shown once, when it is issued.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/workspace"
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	// ScopeAnalyze allows queueing analyses and managing schedules.
+	ScopeAnalyze Scope = "analyze"
+	// ScopeRead allows reading results, jobs and exports.
+	ScopeRead Scope = "read"
+	// ScopeAdmin administers a workspace: it grants the other workspace scopes
+	// and manages the workspace's keys and webhooks.
+	ScopeAdmin Scope = "admin"
+	// ScopeSuperAdmin administers the instance: it grants every scope, manages
+	// the keys and quotas of all workspaces and changes the log level.
+	ScopeSuperAdmin Scope = "superadmin"
+)
+
+// keyPrefix marks API keys so they are easy to recognise, e.g. in secret
+// scanners.
+const keyPrefix = "wa_"
+
+var (
+	// ErrNotFound is returned when a key does not exist.
+	ErrNotFound = errors.New("api key not found")
+	// ErrUnknownScope is returned when issuing a key with an unknown scope.
+	ErrUnknownScope = errors.New("unknown scope")
+)
+
+// Key is an issued API key. The key itself is never stored.
+type Key struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Scopes []Scope `json:"scopes"`
+	// Workspace is the workspace whose data the key reads and writes.
+	Workspace string `json:"workspace"`
+	// Prefix is the start of the key, to help users tell keys apart.
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"hash,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Allows reports whether the key grants scope. The superadmin scope grants all
+// scopes and the admin scope all but superadmin.
+func (k Key) Allows(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeSuperAdmin || (s == ScopeAdmin && scope != ScopeSuperAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Manages reports whether the key may manage the keys of a workspace: its own
+// with the admin scope, any with the superadmin scope.
+func (k Key) Manages(workspaceID string) bool {
+	return k.Allows(ScopeSuperAdmin) || (k.Allows(ScopeAdmin) && k.Workspace == workspaceID)
+}
+
+// Revoked reports whether the key has been revoked.
+func (k Key) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// public returns a copy of the key without its hash.
+func (k Key) public() Key {
+	k.Hash = ""
+	return k
+}
+
+// ParseScope returns the Scope named by s.
+func ParseScope(s string) (Scope, error) {
+	switch scope := Scope(strings.ToLower(s)); scope {
+	case ScopeAnalyze, ScopeRead, ScopeAdmin, ScopeSuperAdmin:
+		return scope, nil
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownScope, s)
+}
+
+// Store keeps API keys, persisted to a JSON file when a path is given.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu     sync.RWMutex
+	keys   map[string]*Key
+	byHash map[string]*Key
+}
+
+// NewStore creates a Store that persists keys to path, loading any that already
+// exist there. Keys are kept in memory only when path is empty.
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:   path,
+		now:    time.Now,
+		keys:   make(map[string]*Key),
+		byHash: make(map[string]*Key),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Issue creates a key with the given scopes in a workspace. The returned secret
+// is the API key to hand to the client; it cannot be recovered later.
+func (s *Store) Issue(name, workspaceID string, scopes []Scope) (Key, string, error) {
+	if err := workspace.ValidateID(workspaceID); err != nil {
+		return Key{}, "", err
+	}
+	if len(scopes) == 0 {
+		return Key{}, "", fmt.Errorf("%w: at least one scope is required", ErrUnknownScope)
+	}
+	for _, scope := range scopes {
+		if _, err := ParseScope(string(scope)); err != nil {
+			return Key{}, "", err
+		}
+	}
+	secret := keyPrefix + randomHex(24)
+	key, err := s.add(name, workspaceID, secret, scopes)
+	if err != nil {
+		return Key{}, "", err
+	}
+	return key, secret, nil
+}
+
+// Ensure registers secret as a key with the given scopes, or updates the scopes
+// of the key when it is already known. It is used to bootstrap the first admin
+// key, in the default workspace, from configuration.
+func (s *Store) Ensure(name, secret string, scopes []Scope) (Key, error) {
+	s.mu.Lock()
+	existing, ok := s.byHash[hash(secret)]
+	if ok {
+		defer s.mu.Unlock()
+		if !slices.Equal(existing.Scopes, scopes) {
+			previous := existing.Scopes
+			existing.Scopes = scopes
+			if err := s.save(); err != nil {
+				existing.Scopes = previous
+				return Key{}, err
+			}
+		}
+		return existing.public(), nil
+	}
+	s.mu.Unlock()
+	return s.add(name, workspace.Default, secret, scopes)
+}
+
+// Get returns the key with the given ID.
+func (s *Store) Get(id string) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return Key{}, ErrNotFound
+	}
+	return key.public(), nil
+}
+
+func (s *Store) add(name, workspaceID, secret string, scopes []Scope) (Key, error) {
+	key := &Key{
+		ID:        randomHex(8),
+		Name:      name,
+		Scopes:    scopes,
+		Workspace: workspaceID,
+		Prefix:    secret[:min(len(secret), len(keyPrefix)+6)],
+		Hash:      hash(secret),
+		CreatedAt: s.now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.ID] = key
+	s.byHash[key.Hash] = key
+	if err := s.save(); err != nil {
+		delete(s.keys, key.ID)
+		delete(s.byHash, key.Hash)
+		return Key{}, err
+	}
+	return key.public(), nil
+}
+
+// Revoke disables a key. Revoked keys stay listed.
+func (s *Store) Revoke(id string) (Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.keys[id]
+	if !ok {
+		return Key{}, ErrNotFound
+	}
+	if key.RevokedAt == nil {
+		now := s.now()
+		key.RevokedAt = &now
+		if err := s.save(); err != nil {
+			key.RevokedAt = nil
+			return Key{}, err
+		}
+	}
+	return key.public(), nil
+}
+
+// List returns all keys, without their hashes, ordered by creation time.
+func (s *Store) List() []Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		list = append(list, key.public())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Authenticate returns the active key matching secret.
+func (s *Store) Authenticate(secret string) (Key, bool) {
+	if secret == "" {
+		return Key{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.byHash[hash(secret)]
+	if !ok || key.Revoked() {
+		return Key{}, false
+	}
+	// Last use is kept in memory only, to avoid a write per request.
+	now := s.now()
+	key.LastUsedAt = &now
+	return key.public(), true
+}
+
+func (s *Store) load() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading api keys: %w", err)
+	}
+	var list []*Key
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("decoding api keys: %w", err)
+	}
+	for _, key := range list {
+		// Keys issued before workspaces existed belong to the default one.
+		if key.Workspace == "" {
+			key.Workspace = workspace.Default
+		}
+		s.keys[key.ID] = key
+		s.byHash[key.Hash] = key
+	}
+	return nil
+}
+
+// save writes all keys to disk atomically. The caller must hold s.mu.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	list := make([]*Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		list = append(list, key)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("creating api keys directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing api keys: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("writing api keys: %w", err)
+	}
+	return nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
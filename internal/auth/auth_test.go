@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"web-analyzer/internal/workspace"
+)
+
+func TestStore_IssueAuthenticateRevoke(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, secret, err := store.Issue("ci", workspace.Default, []Scope{ScopeAnalyze, ScopeRead})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(secret, keyPrefix) || !strings.HasPrefix(secret, key.Prefix) || key.Hash != "" {
+		t.Errorf("unexpected issued key %+v with secret %q", key, secret)
+	}
+
+	got, ok := store.Authenticate(secret)
+	if !ok || got.ID != key.ID || got.LastUsedAt == nil {
+		t.Fatalf("expected the key to authenticate, got %+v, %v", got, ok)
+	}
+	if !got.Allows(ScopeRead) || got.Allows(ScopeAdmin) {
+		t.Errorf("unexpected scopes: %v", got.Scopes)
+	}
+	if _, ok := store.Authenticate("wa_wrong"); ok {
+		t.Error("expected an unknown key to be rejected")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading keys file: %v", err)
+	}
+	if strings.Contains(string(data), secret) || !strings.Contains(string(data), hash(secret)) {
+		t.Error("expected only the key hash to be persisted")
+	}
+
+	if _, err := store.Revoke(key.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := store.Authenticate(secret); ok {
+		t.Error("expected a revoked key to be rejected")
+	}
+	if _, err := store.Revoke("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list := reloaded.List(); len(list) != 1 || !list[0].Revoked() {
+		t.Errorf("expected the revoked key to be reloaded, got %+v", list)
+	}
+}
+
+func TestStore_IssueRejectsUnknownScope(t *testing.T) {
+	store, _ := NewStore("")
+	if _, _, err := store.Issue("x", workspace.Default, []Scope{"superuser"}); !errors.Is(err, ErrUnknownScope) {
+		t.Errorf("expected ErrUnknownScope, got %v", err)
+	}
+	if _, _, err := store.Issue("x", workspace.Default, nil); err == nil {
+		t.Error("expected an error without scopes")
+	}
+}
+
+func TestStore_Ensure(t *testing.T) {
+	store, _ := NewStore("")
+	first, err := store.Ensure("bootstrap", "wa_bootstrap", []Scope{ScopeAdmin})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := store.Ensure("bootstrap", "wa_bootstrap", []Scope{ScopeAdmin})
+	if first.ID != second.ID || len(store.List()) != 1 {
+		t.Error("expected Ensure to be idempotent")
+	}
+	key, ok := store.Authenticate("wa_bootstrap")
+	if !ok || !key.Allows(ScopeAnalyze) {
+		t.Error("expected the admin key to grant every scope")
+	}
+}
+
+func TestKey_AdminScopes(t *testing.T) {
+	admin := Key{Workspace: "team-a", Scopes: []Scope{ScopeAdmin}}
+	if !admin.Allows(ScopeRead) || !admin.Allows(ScopeAdmin) || admin.Allows(ScopeSuperAdmin) {
+		t.Error("expected a workspace admin to grant every scope but superadmin")
+	}
+	if !admin.Manages("team-a") || admin.Manages("team-b") {
+		t.Error("expected a workspace admin to manage its own workspace only")
+	}
+	super := Key{Workspace: workspace.Default, Scopes: []Scope{ScopeSuperAdmin}}
+	if !super.Allows(ScopeSuperAdmin) || !super.Manages("team-b") {
+		t.Error("expected a superadmin to manage every workspace")
+	}
+	if (Key{Workspace: "team-a", Scopes: []Scope{ScopeAnalyze}}).Manages("team-a") {
+		t.Error("expected keys without admin to manage no workspace")
+	}
+}
+
+func TestStore_EnsureUpdatesScopes(t *testing.T) {
+	store, _ := NewStore("")
+	first, _ := store.Ensure("bootstrap", "wa_bootstrap", []Scope{ScopeAdmin})
+	second, err := store.Ensure("bootstrap", "wa_bootstrap", []Scope{ScopeSuperAdmin})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.ID != second.ID || !second.Allows(ScopeSuperAdmin) {
+		t.Errorf("expected the existing key to be granted superadmin, got %+v", second)
+	}
+}
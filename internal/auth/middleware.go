@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/problem"
+	"web-analyzer/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is an alternative to "Authorization: Bearer <key>".
+const APIKeyHeader = "X-API-Key"
+
+// CookieName is the cookie the dashboard keeps the API key in after signing in.
+const CookieName = "wa_api_key"
+
+const contextKey = "auth.key"
+
+// Middleware rejects requests without an active API key granting scope, with
+// 401 for a missing or unknown key and 403 for a key lacking the scope. The key
+// is stored on the gin context, the request is scoped to the key's workspace and
+// both IDs are added to the request logger.
+func Middleware(store *Store, scope Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, ok := authenticate(c, store, secretFromRequest(c.Request))
+		if !ok {
+			c.Header("WWW-Authenticate", `Bearer realm="web-analyzer"`)
+			problem.Abort(c, http.StatusUnauthorized, "Missing or invalid API key")
+			return
+		}
+		if !key.Allows(scope) {
+			problem.Abort(c, http.StatusForbidden, "API key lacks the "+string(scope)+" scope")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RedirectMiddleware is like Middleware for browser pages. It also accepts the
+// key from the CookieName cookie, and redirects requests without a valid key
+// to loginPath instead of responding with a 401.
+func RedirectMiddleware(store *Store, scope Scope, loginPath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := secretFromRequest(c.Request)
+		if cookie, err := c.Request.Cookie(CookieName); err == nil && secret == "" {
+			secret = cookie.Value
+		}
+		key, ok := authenticate(c, store, secret)
+		if !ok {
+			c.Redirect(http.StatusSeeOther, loginPath)
+			c.Abort()
+			return
+		}
+		if !key.Allows(scope) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// authenticate returns the key of the request, authenticating secret on first
+// use.
+func authenticate(c *gin.Context, store *Store, secret string) (Key, bool) {
+	if key, ok := KeyFromContext(c); ok {
+		return key, true
+	}
+	key, ok := store.Authenticate(secret)
+	if !ok {
+		return Key{}, false
+	}
+	c.Set(contextKey, key)
+	ctx := workspace.WithID(c.Request.Context(), key.Workspace)
+	c.Request = c.Request.WithContext(logging.With(ctx, "key_id", key.ID, "workspace", key.Workspace))
+	return key, true
+}
+
+// KeyFromContext returns the key that authenticated the request, if any.
+func KeyFromContext(c *gin.Context) (Key, bool) {
+	value, ok := c.Get(contextKey)
+	if !ok {
+		return Key{}, false
+	}
+	key, ok := value.(Key)
+	return key, ok
+}
+
+// ByKey identifies rate limited clients by their API key. It returns an empty
+// key for unauthenticated requests.
+func ByKey(c *gin.Context) string {
+	if key, ok := KeyFromContext(c); ok {
+		return "key:" + key.ID
+	}
+	return ""
+}
+
+func secretFromRequest(r *http.Request) string {
+	if secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(secret)
+	}
+	return r.Header.Get(APIKeyHeader)
+}
@@ -0,0 +1,168 @@
+// Package crawler analyses a site by following its internal links, breadth
+// first, from a start page.
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/models"
+)
+
+const (
+	// DefaultMaxPages is the number of pages crawled when Options.MaxPages is
+	// zero.
+	DefaultMaxPages = 50
+	// DefaultMaxDepth is the link depth followed when Options.MaxDepth is zero.
+	DefaultMaxDepth = 3
+	// DefaultConcurrency is the number of pages analysed at once when
+	// Options.Concurrency is zero.
+	DefaultConcurrency = 4
+)
+
+// ErrInvalidURL is returned when the start URL is not an absolute http(s) URL.
+var ErrInvalidURL = errors.New("invalid start URL")
+
+// Options bound a crawl.
+type Options struct {
+	// MaxPages is the maximum number of pages analysed.
+	MaxPages int
+	// MaxDepth is the maximum number of links followed from the start page.
+	MaxDepth int
+	// Concurrency is the number of pages analysed at once.
+	Concurrency int
+}
+
+// Page is the outcome of analysing one page of a crawl.
+type Page struct {
+	URL    string                 `json:"url"`
+	Depth  int                    `json:"depth"`
+	Result *models.AnalysisResult `json:"result,omitempty"`
+	Error  string                 `json:"error,omitempty"`
+}
+
+// Crawler analyses pages with an analyzer service.
+type Crawler struct {
+	service analyzer.AnalyzerService
+	opts    Options
+	// OnPage, when set, is called after every page is analysed. Calls are
+	// serialised.
+	OnPage func(Page)
+}
+
+// New creates a Crawler analysing pages with service.
+func New(service analyzer.AnalyzerService, opts Options) *Crawler {
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = DefaultMaxPages
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	return &Crawler{service: service, opts: opts}
+}
+
+// Crawl analyses start and the pages of the same host it links to, level by
+// level, until MaxPages pages have been analysed, MaxDepth is reached or ctx
+// is done. Pages are returned in the order they were discovered.
+func (c *Crawler) Crawl(ctx context.Context, start string) ([]Page, error) {
+	startURL, err := url.Parse(start)
+	if err != nil || !startURL.IsAbs() || (startURL.Scheme != "http" && startURL.Scheme != "https") {
+		return nil, ErrInvalidURL
+	}
+	host := strings.ToLower(startURL.Host)
+
+	startURL.Fragment = ""
+	seen := map[string]bool{startURL.String(): true}
+	level := []string{startURL.String()}
+	var pages []Page
+	var mu sync.Mutex
+
+	for depth := 0; len(level) > 0 && depth <= c.opts.MaxDepth; depth++ {
+		if remaining := c.opts.MaxPages - len(pages); len(level) > remaining {
+			level = level[:remaining]
+		}
+		results := make([]Page, len(level))
+		sem := make(chan struct{}, c.opts.Concurrency)
+		var wg sync.WaitGroup
+		for i, pageURL := range level {
+			if ctx.Err() != nil {
+				break
+			}
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				page := c.analyse(ctx, pageURL, depth)
+				results[i] = page
+				if c.OnPage != nil {
+					mu.Lock()
+					c.OnPage(page)
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return appendDone(pages, results), err
+		}
+		pages = append(pages, results...)
+		if len(pages) >= c.opts.MaxPages {
+			break
+		}
+
+		var next []string
+		for _, page := range results {
+			if page.Result == nil {
+				continue
+			}
+			for _, link := range page.Result.Links {
+				if u, ok := sameHost(link, host); ok && !seen[u] {
+					seen[u] = true
+					next = append(next, u)
+				}
+			}
+		}
+		level = next
+	}
+	return pages, nil
+}
+
+func (c *Crawler) analyse(ctx context.Context, pageURL string, depth int) Page {
+	page := Page{URL: pageURL, Depth: depth}
+	if err := c.service.AnalyzePage(ctx, pageURL); err != nil {
+		page.Error = err.Error()
+		return page
+	}
+	if result, ok := c.service.GetAnalysis(pageURL); ok {
+		page.Result = &result
+	}
+	return page
+}
+
+// appendDone appends the pages of an interrupted level that were analysed.
+func appendDone(pages, level []Page) []Page {
+	for _, page := range level {
+		if page.URL != "" {
+			pages = append(pages, page)
+		}
+	}
+	return pages
+}
+
+// sameHost normalises link and reports whether it is an http(s) URL on host.
+func sameHost(link, host string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || strings.ToLower(u.Host) != host {
+		return "", false
+	}
+	u.Fragment = ""
+	return u.String(), true
+}
@@ -0,0 +1,102 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"web-analyzer/models"
+)
+
+// siteService serves a fixed link graph instead of fetching pages.
+type siteService struct {
+	mu       sync.Mutex
+	links    map[string][]string
+	analysed []string
+}
+
+func (s *siteService) AnalyzePage(ctx context.Context, url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analysed = append(s.analysed, url)
+	if _, ok := s.links[url]; !ok {
+		return errors.New("not found")
+	}
+	return nil
+}
+
+func (s *siteService) GetAnalysis(url string) (models.AnalysisResult, bool) {
+	links, ok := s.links[url]
+	return models.AnalysisResult{URL: url, Status: "Completed", Links: links}, ok
+}
+
+func (s *siteService) ListAnalyses() []models.AnalysisResult { return nil }
+
+func (s *siteService) GetHistory(url string) []models.Snapshot { return nil }
+
+func (s *siteService) GetSubmittedUrls() []string { return nil }
+
+func (s *siteService) ListUrls() []models.URLEntry { return nil }
+
+func (s *siteService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	return models.AnalysisResult{}, nil
+}
+
+func newSite() *siteService {
+	return &siteService{links: map[string][]string{
+		"https://example.com/":  {"https://example.com/a", "https://example.com/b#top", "https://other.com/", "mailto:me@example.com"},
+		"https://example.com/a": {"https://example.com/", "https://example.com/c"},
+		"https://example.com/b": {"https://example.com/missing"},
+		"https://example.com/c": nil,
+	}}
+}
+
+func TestCrawl_FollowsInternalLinks(t *testing.T) {
+	site := newSite()
+	pages, err := New(site, Options{Concurrency: 1}).Crawl(context.Background(), "https://example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]int{
+		"https://example.com/":        0,
+		"https://example.com/a":       1,
+		"https://example.com/b":       1,
+		"https://example.com/c":       2,
+		"https://example.com/missing": 2,
+	}
+	if len(pages) != len(want) {
+		t.Fatalf("expected %d pages, got %+v", len(want), pages)
+	}
+	for _, page := range pages {
+		depth, ok := want[page.URL]
+		if !ok || depth != page.Depth {
+			t.Errorf("unexpected page %s at depth %d", page.URL, page.Depth)
+		}
+		if page.URL == "https://example.com/missing" && page.Error == "" {
+			t.Error("expected the missing page to report an error")
+		}
+	}
+}
+
+func TestCrawl_Limits(t *testing.T) {
+	pages, _ := New(newSite(), Options{MaxPages: 2}).Crawl(context.Background(), "https://example.com/")
+	if len(pages) != 2 {
+		t.Errorf("expected MaxPages to stop the crawl at 2 pages, got %d", len(pages))
+	}
+
+	pages, _ = New(newSite(), Options{MaxDepth: 1}).Crawl(context.Background(), "https://example.com/")
+	for _, page := range pages {
+		if page.Depth > 1 {
+			t.Errorf("expected MaxDepth to stop at depth 1, got %s at %d", page.URL, page.Depth)
+		}
+	}
+}
+
+func TestCrawl_InvalidURL(t *testing.T) {
+	if _, err := New(newSite(), Options{}).Crawl(context.Background(), "example.com"); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("expected ErrInvalidURL, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Package debugserver serves the pprof endpoints on a separate, authenticated
+// admin listener. It is disabled unless explicitly switched on, so profiles and
+// heap dumps are never exposed on the public port.
+package debugserver
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultAddr = "localhost:6060"
+
+var (
+	// ErrNoToken is returned when the debug server is enabled without a token.
+	ErrNoToken = errors.New("debug server requires ADMIN_TOKEN")
+	// ErrAlreadyStarted is returned when Start is called more than once.
+	ErrAlreadyStarted = errors.New("debug server already started")
+)
+
+// Config controls the debug server.
+type Config struct {
+	// Enabled switches the debug server on.
+	Enabled bool
+	// Addr is the address the admin listener binds to.
+	Addr string
+	// Token must be presented as "Authorization: Bearer <token>".
+	Token string
+}
+
+// ConfigFromEnv reads DEBUG_ENDPOINTS, ADMIN_ADDR (default localhost:6060) and
+// ADMIN_TOKEN.
+func ConfigFromEnv() Config {
+	enabled, _ := strconv.ParseBool(os.Getenv("DEBUG_ENDPOINTS"))
+	addr := os.Getenv("ADMIN_ADDR")
+	if addr == "" {
+		addr = defaultAddr
+	}
+	return Config{Enabled: enabled, Addr: addr, Token: os.Getenv("ADMIN_TOKEN")}
+}
+
+// Handler returns the pprof endpoints under /debug/pprof/, requiring token.
+func Handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return requireToken(token, mux)
+}
+
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+var started sync.Once
+
+// Start launches the admin listener in the background when cfg enables it. It
+// returns nil without starting anything when the server is disabled, and
+// ErrAlreadyStarted on any call after the first.
+func Start(cfg Config) (*http.Server, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Token == "" {
+		return nil, ErrNoToken
+	}
+	err := ErrAlreadyStarted
+	var srv *http.Server
+	started.Do(func() {
+		err = nil
+		srv = &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           Handler(cfg.Token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Debug server failed", "addr", cfg.Addr, "error", err)
+			}
+		}()
+		slog.Info("Debug server started", "addr", cfg.Addr)
+	})
+	return srv, err
+}
@@ -0,0 +1,61 @@
+package debugserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_RequiresToken(t *testing.T) {
+	h := Handler("s3cret")
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer nope", http.StatusUnauthorized},
+		{"wrong scheme", "Basic s3cret", http.StatusUnauthorized},
+		{"valid", "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			if resp.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, resp.Code)
+			}
+		})
+	}
+}
+
+func TestStart_Disabled(t *testing.T) {
+	srv, err := Start(Config{Enabled: false, Token: "s3cret"})
+	if srv != nil || err != nil {
+		t.Errorf("expected nothing to start, got %v, %v", srv, err)
+	}
+}
+
+func TestStart_RequiresToken(t *testing.T) {
+	if _, err := Start(Config{Enabled: true, Addr: "localhost:0"}); !errors.Is(err, ErrNoToken) {
+		t.Errorf("expected ErrNoToken, got %v", err)
+	}
+}
+
+func TestStart_OnlyOnce(t *testing.T) {
+	cfg := Config{Enabled: true, Addr: "localhost:0", Token: "s3cret"}
+	srv, err := Start(cfg)
+	if err != nil || srv == nil {
+		t.Fatalf("expected the server to start, got %v", err)
+	}
+	defer srv.Close()
+	if _, err := Start(cfg); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("expected ErrAlreadyStarted, got %v", err)
+	}
+}
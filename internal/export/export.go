@@ -0,0 +1,301 @@
+// Package export renders analysis results as CSV, JSONL or a self-contained
+// HTML report.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"web-analyzer/internal/performance"
+	"web-analyzer/internal/security"
+	"web-analyzer/internal/structureddata"
+	"web-analyzer/models"
+)
+
+// Format is an export output format.
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+	FormatHTML  Format = "html"
+)
+
+// ParseFormat returns the Format named by s. It defaults to JSONL when s is
+// empty.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatJSONL:
+		return FormatJSONL, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	}
+	return "", fmt.Errorf("unsupported export format %q", s)
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Filter selects the analyses to export. Zero-valued fields match everything.
+type Filter struct {
+	URLs   []string
+	From   time.Time
+	To     time.Time
+	Status string
+	Host   string
+}
+
+// Apply returns the results matching the filter.
+func (f Filter) Apply(results []models.AnalysisResult) []models.AnalysisResult {
+	urls := make(map[string]bool, len(f.URLs))
+	for _, u := range f.URLs {
+		urls[u] = true
+	}
+
+	var matched []models.AnalysisResult
+	for _, r := range results {
+		if len(urls) > 0 && !urls[r.URL] {
+			continue
+		}
+		if !f.From.IsZero() && r.AnalyzedAt.Before(f.From) {
+			continue
+		}
+		if !f.To.IsZero() && r.AnalyzedAt.After(f.To) {
+			continue
+		}
+		if f.Status != "" && !strings.EqualFold(r.Status, f.Status) {
+			continue
+		}
+		if f.Host != "" && !strings.EqualFold(hostOf(r.URL), f.Host) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	return matched
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// Write renders results to w in the given format.
+func Write(w io.Writer, format Format, results []models.AnalysisResult) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, results)
+	case FormatHTML:
+		return WriteHTML(w, results)
+	}
+	return WriteJSONL(w, results)
+}
+
+var csvHeader = []string{
+	"URL", "Status", "Analyzed At", "HTML Version", "Title",
+	"H1", "H2", "H3", "H4", "H5", "H6",
+	"Internal Links", "External Links", "Broken Links", "Login Form",
+	"Final URL", "Redirects", "Findings",
+}
+
+// WriteCSV writes one row per result, with headings flattened into columns.
+// Text cells are escaped so that spreadsheets do not evaluate them as formulas.
+func WriteCSV(w io.Writer, results []models.AnalysisResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, r := range results {
+		redirects := 0
+		if len(r.RedirectChain) > 0 {
+			redirects = len(r.RedirectChain) - 1
+		}
+		row := []string{
+			escapeCell(r.URL), r.Status, formatTime(r.AnalyzedAt), escapeCell(r.HTMLVersion), escapeCell(r.Title),
+		}
+		for _, level := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
+			row = append(row, strconv.Itoa(r.Headings[level]))
+		}
+		row = append(row,
+			strconv.Itoa(r.InternalLinks),
+			strconv.Itoa(r.ExternalLinks),
+			strconv.Itoa(r.BrokenLinks),
+			escapeCell(r.LoginForm),
+			escapeCell(r.FinalURL),
+			strconv.Itoa(redirects),
+			escapeCell(strings.Join(Findings(r), "; ")),
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeCell prefixes a cell that a spreadsheet would read as a formula with a
+// single quote, which displays it as text. Analysed pages control titles and
+// URLs, so their content must not run in the spreadsheet of whoever exports it.
+func escapeCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// WriteJSONL writes one JSON encoded result per line.
+func WriteJSONL(w io.Writer, results []models.AnalysisResult) error {
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteHTML writes a standalone HTML report with inline styles.
+func WriteHTML(w io.Writer, results []models.AnalysisResult) error {
+	data := struct {
+		GeneratedAt string
+		Results     []reportEntry
+	}{GeneratedAt: formatTime(time.Now())}
+	for _, r := range results {
+		data.Results = append(data.Results, reportEntry{
+			AnalysisResult: r,
+			AnalyzedAtText: formatTime(r.AnalyzedAt),
+			HeadingLevels:  headingLevels(r.Headings),
+			Findings:       Findings(r),
+		})
+	}
+	return reportTemplate.Execute(w, data)
+}
+
+type reportEntry struct {
+	models.AnalysisResult
+	AnalyzedAtText string
+	HeadingLevels  []headingCount
+	Findings       []string
+}
+
+type headingCount struct {
+	Level string
+	Count int
+}
+
+func headingLevels(headings map[string]int) []headingCount {
+	levels := make([]headingCount, 0, len(headings))
+	for level, count := range headings {
+		levels = append(levels, headingCount{Level: level, Count: count})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Level < levels[j].Level })
+	return levels
+}
+
+// Findings lists the notable problems of a result in human readable form.
+func Findings(r models.AnalysisResult) []string {
+	var findings []string
+	if r.RedirectLoop {
+		findings = append(findings, "Redirect loop detected")
+	}
+	if r.HTTPSDowngrade {
+		findings = append(findings, "Redirect downgrades HTTPS to HTTP")
+	}
+	if r.LongRedirectChain {
+		findings = append(findings, fmt.Sprintf("Long redirect chain (%d hops)", len(r.RedirectChain)-1))
+	}
+	if r.Status != "Completed" {
+		return findings
+	}
+	if r.Title == "" {
+		findings = append(findings, "Missing title")
+	}
+	if r.Headings["h1"] == 0 {
+		findings = append(findings, "No h1 heading")
+	} else if r.Headings["h1"] > 1 {
+		findings = append(findings, fmt.Sprintf("Multiple h1 headings (%d)", r.Headings["h1"]))
+	}
+	if r.BrokenLinks > 0 {
+		findings = append(findings, fmt.Sprintf("%d broken links", r.BrokenLinks))
+	}
+	if r.Resources != nil && r.Resources.Broken > 0 {
+		findings = append(findings, fmt.Sprintf("%d broken resources", r.Resources.Broken))
+	}
+	findings = append(findings, security.Issues(r.Security)...)
+	findings = append(findings, performance.Issues(r.Performance)...)
+	findings = append(findings, structureddata.Issues(r.StructuredData)...)
+	return findings
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Web Analyzer Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #222; }
+section { border: 1px solid #ddd; border-radius: 6px; padding: 1rem 1.5rem; margin-bottom: 1.5rem; }
+h2 { margin-top: 0; word-break: break-all; }
+table { border-collapse: collapse; margin: 0.5rem 0; }
+th, td { text-align: left; padding: 0.25rem 0.75rem; border-bottom: 1px solid #eee; }
+.findings li { color: #a33; }
+.meta { color: #666; font-size: 0.9rem; }
+</style>
+</head>
+<body>
+<h1>Web Analyzer Report</h1>
+<p class="meta">Generated {{.GeneratedAt}} &middot; {{len .Results}} analyses</p>
+{{range .Results}}
+<section>
+<h2>{{.URL}}</h2>
+<p class="meta">{{.Status}}{{if .AnalyzedAtText}} &middot; analyzed {{.AnalyzedAtText}}{{end}}{{if and .FinalURL (ne .FinalURL .URL)}} &middot; final URL {{.FinalURL}}{{end}}</p>
+<table>
+<tr><th>Title</th><td>{{.Title}}</td></tr>
+<tr><th>HTML Version</th><td>{{.HTMLVersion}}</td></tr>
+<tr><th>Login Form</th><td>{{.LoginForm}}</td></tr>
+</table>
+<h3>Headings</h3>
+{{if .HeadingLevels}}<table>{{range .HeadingLevels}}<tr><th>{{.Level}}</th><td>{{.Count}}</td></tr>{{end}}</table>{{else}}<p>None</p>{{end}}
+<h3>Links</h3>
+<table>
+<tr><th>Internal</th><td>{{.InternalLinks}}</td></tr>
+<tr><th>External</th><td>{{.ExternalLinks}}</td></tr>
+<tr><th>Broken</th><td>{{.BrokenLinks}}</td></tr>
+</table>
+{{if .BrokenLinkURLs}}<h3>Broken Links</h3>
+<ul>{{range .BrokenLinkURLs}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<h3>Findings</h3>
+{{if .Findings}}<ul class="findings">{{range .Findings}}<li>{{.}}</li>{{end}}</ul>{{else}}<p>No issues found</p>{{end}}
+</section>
+{{else}}
+<p>No analyses match the selected filters.</p>
+{{end}}
+</body>
+</html>
+`))
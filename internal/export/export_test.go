@@ -0,0 +1,133 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+	"web-analyzer/models"
+)
+
+func sampleResults() []models.AnalysisResult {
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	return []models.AnalysisResult{
+		{
+			URL:            "https://example.com",
+			Status:         "Completed",
+			Title:          "Example",
+			Headings:       map[string]int{"h1": 1, "h2": 2},
+			BrokenLinks:    1,
+			BrokenLinkURLs: []string{"https://example.com/missing"},
+			AnalyzedAt:     day,
+		},
+		{
+			URL:        "https://other.org/page",
+			Status:     "Failed",
+			Headings:   map[string]int{},
+			AnalyzedAt: day.AddDate(0, 0, 2),
+		},
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	results := sampleResults()
+
+	if got := (Filter{Host: "example.com"}).Apply(results); len(got) != 1 || got[0].URL != "https://example.com" {
+		t.Errorf("host filter returned %v", got)
+	}
+	if got := (Filter{Status: "failed"}).Apply(results); len(got) != 1 || got[0].Status != "Failed" {
+		t.Errorf("status filter returned %v", got)
+	}
+	from := time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)
+	if got := (Filter{From: from}).Apply(results); len(got) != 1 || got[0].URL != "https://other.org/page" {
+		t.Errorf("date filter returned %v", got)
+	}
+	if got := (Filter{}).Apply(results); len(got) != 2 {
+		t.Errorf("empty filter returned %d results", len(got))
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	if rows[1][0] != "https://example.com" || rows[1][5] != "1" || rows[1][6] != "2" {
+		t.Errorf("unexpected row: %v", rows[1])
+	}
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	results := []models.AnalysisResult{{
+		URL:      "https://example.com",
+		Status:   "Completed",
+		Title:    `=HYPERLINK("https://evil.example","click")`,
+		FinalURL: "@SUM(1+1)",
+		Headings: map[string]int{},
+	}}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if got := rows[1][4]; got != `'=HYPERLINK("https://evil.example","click")` {
+		t.Errorf("title = %q", got)
+	}
+	if got := rows[1][15]; got != "'@SUM(1+1)" {
+		t.Errorf("final URL = %q", got)
+	}
+	if got := rows[1][0]; got != "https://example.com" {
+		t.Errorf("URL = %q", got)
+	}
+
+	for _, tt := range []struct{ in, want string }{
+		{"=1+1", "'=1+1"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@x", "'@x"},
+		{"\tx", "'\tx"},
+		{"\rx", "'\rx"},
+		{"", ""},
+		{"a=b", "a=b"},
+	} {
+		if got := escapeCell(tt.in); got != tt.want {
+			t.Errorf("escapeCell(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, sampleResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"https://example.com/missing", "1 broken links", "<h2>https://other.org/page</h2>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+// Package fingerprint detects the technologies a page is built with, such as
+// its CMS, JavaScript frameworks and analytics services. Detection is driven by
+// rules, so signatures can be added in JSON without code changes.
+package fingerprint
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"web-analyzer/internal/htmlutil"
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+//go:embed rules.json
+var defaultRules []byte
+
+// Rule describes how to recognise a technology. Every pattern is a regular
+// expression and the first non-empty capture group of a match is taken as the
+// technology's version. An empty pattern only requires the header, cookie,
+// meta tag or attribute to be present.
+type Rule struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Meta maps the names of <meta> tags, such as "generator", to patterns of
+	// their content.
+	Meta map[string]string `json:"meta,omitempty"`
+	// ScriptSrc lists patterns of the src of <script> elements.
+	ScriptSrc []string `json:"script_src,omitempty"`
+	// Globals lists patterns of the content of inline scripts, typically the
+	// globals a library defines or calls.
+	Globals []string `json:"globals,omitempty"`
+	// Headers maps response header names to patterns of their value.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Cookies maps the names of cookies set by the response to patterns of
+	// their value. A name ending in "*" matches any name with that prefix.
+	Cookies map[string]string `json:"cookies,omitempty"`
+	// Attributes maps names of element attributes to patterns of their value.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Implies lists technologies that are detected along with this one.
+	Implies []string `json:"implies,omitempty"`
+}
+
+// Set is a compiled list of rules.
+type Set struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	meta       map[string]*regexp.Regexp
+	scriptSrc  []*regexp.Regexp
+	globals    []*regexp.Regexp
+	headers    map[string]*regexp.Regexp
+	cookies    map[string]*regexp.Regexp
+	attributes map[string]*regexp.Regexp
+}
+
+// Default returns the rules shipped with the analyzer.
+func Default() *Set {
+	return defaultSet
+}
+
+var defaultSet = mustParse(defaultRules)
+
+func mustParse(data []byte) *Set {
+	rules, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		panic(fmt.Sprintf("fingerprint: invalid default rules: %v", err))
+	}
+	set, err := Compile(rules)
+	if err != nil {
+		panic(fmt.Sprintf("fingerprint: invalid default rules: %v", err))
+	}
+	return set
+}
+
+// Parse reads a JSON array of rules.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	if err := json.NewDecoder(r).Decode(&rules); err != nil {
+		return nil, fmt.Errorf("decoding rules: %w", err)
+	}
+	return rules, nil
+}
+
+// LoadFile returns the default rules extended with the rules of a JSON file.
+// A rule of the file replaces the default rule with the same name.
+func LoadFile(path string) (*Set, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	extra, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	rules, _ := Parse(bytes.NewReader(defaultRules))
+	index := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		index[rule.Name] = i
+	}
+	for _, rule := range extra {
+		if i, ok := index[rule.Name]; ok {
+			rules[i] = rule
+			continue
+		}
+		index[rule.Name] = len(rules)
+		rules = append(rules, rule)
+	}
+	set, err := Compile(rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return set, nil
+}
+
+// Compile compiles the patterns of rules.
+func Compile(rules []Rule) (*Set, error) {
+	set := &Set{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule without a name")
+		}
+		c := compiledRule{Rule: rule}
+		var err error
+		if c.meta, err = compileMap(rule.Meta, strings.ToLower); err != nil {
+			return nil, fmt.Errorf("rule %s: meta: %w", rule.Name, err)
+		}
+		if c.headers, err = compileMap(rule.Headers, http.CanonicalHeaderKey); err != nil {
+			return nil, fmt.Errorf("rule %s: headers: %w", rule.Name, err)
+		}
+		if c.cookies, err = compileMap(rule.Cookies, nil); err != nil {
+			return nil, fmt.Errorf("rule %s: cookies: %w", rule.Name, err)
+		}
+		if c.attributes, err = compileMap(rule.Attributes, strings.ToLower); err != nil {
+			return nil, fmt.Errorf("rule %s: attributes: %w", rule.Name, err)
+		}
+		if c.scriptSrc, err = compileList(rule.ScriptSrc); err != nil {
+			return nil, fmt.Errorf("rule %s: script_src: %w", rule.Name, err)
+		}
+		if c.globals, err = compileList(rule.Globals); err != nil {
+			return nil, fmt.Errorf("rule %s: globals: %w", rule.Name, err)
+		}
+		set.rules = append(set.rules, c)
+	}
+	return set, nil
+}
+
+func compileMap(patterns map[string]string, key func(string) string) (map[string]*regexp.Regexp, error) {
+	compiled := make(map[string]*regexp.Regexp, len(patterns))
+	for name, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil {
+			name = key(name)
+		}
+		compiled[name] = re
+	}
+	return compiled, nil
+}
+
+func compileList(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// signals are the parts of a page the rules are matched against.
+type signals struct {
+	meta       map[string][]string
+	scriptSrc  []string
+	inline     []string
+	attributes map[string][]string
+	header     http.Header
+	cookies    []*http.Cookie
+}
+
+// Detect returns the technologies of a parsed page and the headers of its
+// response, ordered by name. header may be nil, e.g. for uploaded documents.
+func (s *Set) Detect(doc *html.Node, header http.Header) []models.Technology {
+	sig := collect(doc)
+	sig.header = header
+	sig.cookies = (&http.Response{Header: header}).Cookies()
+
+	found := make(map[string]models.Technology)
+	var implied []string
+	for _, rule := range s.rules {
+		version, ok := rule.match(sig)
+		if !ok {
+			continue
+		}
+		found[rule.Name] = models.Technology{Name: rule.Name, Category: rule.Category, Version: version}
+		implied = append(implied, rule.Implies...)
+	}
+	for len(implied) > 0 {
+		name := implied[0]
+		implied = implied[1:]
+		if _, ok := found[name]; ok {
+			continue
+		}
+		tech := models.Technology{Name: name}
+		for _, rule := range s.rules {
+			if rule.Name == name {
+				tech.Category = rule.Category
+				implied = append(implied, rule.Implies...)
+				break
+			}
+		}
+		found[name] = tech
+	}
+
+	techs := make([]models.Technology, 0, len(found))
+	for _, tech := range found {
+		techs = append(techs, tech)
+	}
+	sort.Slice(techs, func(i, j int) bool { return techs[i].Name < techs[j].Name })
+	return techs
+}
+
+// match reports whether any pattern of r matches and returns the first version
+// captured.
+func (r compiledRule) match(sig signals) (string, bool) {
+	var version string
+	matched := false
+	try := func(re *regexp.Regexp, value string) {
+		m := re.FindStringSubmatch(value)
+		if m == nil {
+			return
+		}
+		matched = true
+		if version == "" {
+			for _, group := range m[1:] {
+				if group != "" {
+					version = group
+					break
+				}
+			}
+		}
+	}
+
+	for name, re := range r.meta {
+		for _, content := range sig.meta[name] {
+			try(re, content)
+		}
+	}
+	for _, re := range r.scriptSrc {
+		for _, src := range sig.scriptSrc {
+			try(re, src)
+		}
+	}
+	for _, re := range r.globals {
+		for _, script := range sig.inline {
+			try(re, script)
+		}
+	}
+	for name, re := range r.attributes {
+		for _, value := range sig.attributes[name] {
+			try(re, value)
+		}
+	}
+	for name, re := range r.headers {
+		for _, value := range sig.header.Values(name) {
+			try(re, value)
+		}
+	}
+	for name, re := range r.cookies {
+		prefix, wildcard := strings.CutSuffix(name, "*")
+		for _, cookie := range sig.cookies {
+			if cookie.Name == name || (wildcard && strings.HasPrefix(cookie.Name, prefix)) {
+				try(re, cookie.Value)
+			}
+		}
+	}
+	return version, matched
+}
+
+// collect gathers the meta tags, scripts and attributes of a document.
+func collect(doc *html.Node) signals {
+	sig := signals{meta: map[string][]string{}, attributes: map[string][]string{}}
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			for _, attr := range n.Attr {
+				sig.attributes[attr.Key] = append(sig.attributes[attr.Key], attr.Val)
+			}
+			switch n.Data {
+			case "meta":
+				if name, _ := htmlutil.Attr(n, "name"); name != "" {
+					content, _ := htmlutil.Attr(n, "content")
+					sig.meta[strings.ToLower(name)] = append(sig.meta[strings.ToLower(name)], content)
+				}
+			case "script":
+				if src, _ := htmlutil.Attr(n, "src"); src != "" {
+					sig.scriptSrc = append(sig.scriptSrc, src)
+				} else if n.FirstChild != nil && n.FirstChild.Type == html.TextNode {
+					sig.inline = append(sig.inline, n.FirstChild.Data)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	if doc != nil {
+		traverse(doc)
+	}
+	return sig
+}
@@ -0,0 +1,146 @@
+package fingerprint
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+func parse(t *testing.T, src string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return doc
+}
+
+func find(techs []models.Technology, name string) (models.Technology, bool) {
+	for _, tech := range techs {
+		if tech.Name == name {
+			return tech, true
+		}
+	}
+	return models.Technology{}, false
+}
+
+func TestDetect_DefaultRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		header  http.Header
+		want    string
+		version string
+	}{
+		{
+			name:    "meta generator",
+			page:    `<meta name="generator" content="WordPress 6.4.2">`,
+			want:    "WordPress",
+			version: "6.4.2",
+		},
+		{
+			name:    "script src",
+			page:    `<script src="https://code.jquery.com/jquery-3.7.1.min.js"></script>`,
+			want:    "jQuery",
+			version: "3.7.1",
+		},
+		{
+			name: "inline global",
+			page: `<script>window.dataLayer = window.dataLayer || []; gtag('config', 'G-123');</script>`,
+			want: "Google Analytics",
+		},
+		{
+			name:    "attribute",
+			page:    `<app-root ng-version="17.0.8"></app-root>`,
+			want:    "Angular",
+			version: "17.0.8",
+		},
+		{
+			name:    "header",
+			header:  http.Header{"Server": {"nginx/1.25.3"}},
+			want:    "Nginx",
+			version: "1.25.3",
+		},
+		{
+			name:   "cookie",
+			header: http.Header{"Set-Cookie": {"_shopify_y=abc; Path=/"}},
+			want:   "Shopify",
+		},
+		{
+			name:   "cookie prefix",
+			header: http.Header{"Set-Cookie": {"wordpress_logged_in_123=abc"}},
+			want:   "WordPress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			techs := Default().Detect(parse(t, tt.page), tt.header)
+			tech, ok := find(techs, tt.want)
+			if !ok {
+				t.Fatalf("expected %s in %+v", tt.want, techs)
+			}
+			if tech.Version != tt.version {
+				t.Errorf("expected version %q, got %q", tt.version, tech.Version)
+			}
+		})
+	}
+}
+
+func TestDetect_Implies(t *testing.T) {
+	techs := Default().Detect(parse(t, `<script src="/_next/static/chunks/main.js"></script>`), nil)
+	react, ok := find(techs, "React")
+	if _, next := find(techs, "Next.js"); !next || !ok {
+		t.Fatalf("expected Next.js and React in %+v", techs)
+	}
+	if react.Category != "JavaScript framework" {
+		t.Errorf("expected the category of the React rule, got %q", react.Category)
+	}
+}
+
+func TestDetect_NothingFound(t *testing.T) {
+	techs := Default().Detect(parse(t, `<p>Hello</p>`), nil)
+	if len(techs) != 0 {
+		t.Errorf("expected no technologies, got %+v", techs)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	rules := `[
+		{"name": "Acme CMS", "category": "CMS", "meta": {"generator": "^Acme ([\\d.]+)"}},
+		{"name": "Nginx", "category": "Reverse proxy", "headers": {"Server": "nginx"}}
+	]`
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	techs := set.Detect(parse(t, `<meta name="Generator" content="Acme 2.1"><script src="jquery.min.js"></script>`), http.Header{"Server": {"nginx"}})
+	if tech, ok := find(techs, "Acme CMS"); !ok || tech.Version != "2.1" {
+		t.Errorf("expected Acme CMS 2.1 in %+v", techs)
+	}
+	if tech, ok := find(techs, "Nginx"); !ok || tech.Category != "Reverse proxy" {
+		t.Errorf("expected the replaced Nginx rule in %+v", techs)
+	}
+	if _, ok := find(techs, "jQuery"); !ok {
+		t.Errorf("expected the default rules to remain in %+v", techs)
+	}
+}
+
+func TestCompile_InvalidPattern(t *testing.T) {
+	if _, err := Compile([]Rule{{Name: "Broken", ScriptSrc: []string{"("}}}); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+	if _, err := Compile([]Rule{{Category: "CMS"}}); err == nil {
+		t.Error("expected an error for a rule without a name")
+	}
+}
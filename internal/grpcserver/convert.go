@@ -0,0 +1,273 @@
+package grpcserver
+
+import (
+	"time"
+
+	v1 "web-analyzer/internal/api/v1"
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/models"
+	pb "web-analyzer/proto/webanalyzer/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var analysisStatuses = map[v1.AnalysisStatus]pb.AnalysisStatus{
+	v1.AnalysisInProgress: pb.AnalysisStatus_ANALYSIS_STATUS_IN_PROGRESS,
+	v1.AnalysisCompleted:  pb.AnalysisStatus_ANALYSIS_STATUS_COMPLETED,
+	v1.AnalysisFailed:     pb.AnalysisStatus_ANALYSIS_STATUS_FAILED,
+}
+
+var headerStatuses = map[v1.HeaderStatus]pb.HeaderStatus{
+	v1.HeaderGood:    pb.HeaderStatus_HEADER_STATUS_GOOD,
+	v1.HeaderWeak:    pb.HeaderStatus_HEADER_STATUS_WEAK,
+	v1.HeaderMissing: pb.HeaderStatus_HEADER_STATUS_MISSING,
+}
+
+var resourceTypes = map[v1.ResourceType]pb.ResourceType{
+	v1.ResourceScript:     pb.ResourceType_RESOURCE_TYPE_SCRIPT,
+	v1.ResourceStylesheet: pb.ResourceType_RESOURCE_TYPE_STYLESHEET,
+	v1.ResourceImage:      pb.ResourceType_RESOURCE_TYPE_IMAGE,
+	v1.ResourceIframe:     pb.ResourceType_RESOURCE_TYPE_IFRAME,
+	v1.ResourceMedia:      pb.ResourceType_RESOURCE_TYPE_MEDIA,
+	v1.ResourceCSS:        pb.ResourceType_RESOURCE_TYPE_CSS,
+}
+
+var structuredDataFormats = map[v1.StructuredDataFormat]pb.StructuredDataFormat{
+	v1.FormatJSONLD:    pb.StructuredDataFormat_STRUCTURED_DATA_FORMAT_JSON_LD,
+	v1.FormatMicrodata: pb.StructuredDataFormat_STRUCTURED_DATA_FORMAT_MICRODATA,
+	v1.FormatRDFa:      pb.StructuredDataFormat_STRUCTURED_DATA_FORMAT_RDFA,
+}
+
+var jobStatuses = map[jobs.Status]pb.JobStatus{
+	jobs.StatusQueued:    pb.JobStatus_JOB_STATUS_QUEUED,
+	jobs.StatusRunning:   pb.JobStatus_JOB_STATUS_RUNNING,
+	jobs.StatusCompleted: pb.JobStatus_JOB_STATUS_COMPLETED,
+	jobs.StatusFailed:    pb.JobStatus_JOB_STATUS_FAILED,
+}
+
+// legacyStatus returns the status stored in models for a requested status, or
+// an empty string for ANALYSIS_STATUS_UNSPECIFIED.
+func legacyStatus(s pb.AnalysisStatus) string {
+	for status, value := range analysisStatuses {
+		if value == s {
+			return status.Legacy()
+		}
+	}
+	return ""
+}
+
+func toJob(j jobs.Job) *pb.Job {
+	return &pb.Job{
+		Id:         j.ID,
+		Workspace:  j.Workspace,
+		Url:        j.URL,
+		BatchId:    j.BatchID,
+		Status:     jobStatuses[j.Status],
+		Error:      j.Error,
+		CreatedAt:  timestamp(&j.CreatedAt),
+		StartedAt:  timestamp(j.StartedAt),
+		FinishedAt: timestamp(j.FinishedAt),
+	}
+}
+
+// toAnalysis converts a result through its /v1 representation, so both APIs
+// derive statuses and flags the same way.
+func toAnalysis(r *models.AnalysisResult) *pb.Analysis {
+	if r == nil {
+		return nil
+	}
+	a := v1.NewAnalysis(*r)
+	analysis := &pb.Analysis{
+		Url:         a.URL,
+		Status:      analysisStatuses[a.Status],
+		Error:       a.Error,
+		HtmlVersion: a.HTMLVersion,
+		Title:       a.Title,
+		Headings: &pb.Headings{
+			H1: int32(a.Headings.H1),
+			H2: int32(a.Headings.H2),
+			H3: int32(a.Headings.H3),
+			H4: int32(a.Headings.H4),
+			H5: int32(a.Headings.H5),
+			H6: int32(a.Headings.H6),
+		},
+		Links: &pb.LinkSummary{
+			Internal:   int32(a.Links.Internal),
+			External:   int32(a.Links.External),
+			Broken:     int32(a.Links.Broken),
+			BrokenUrls: a.Links.BrokenURLs,
+			Urls:       a.Links.URLs,
+		},
+		HasLoginForm: a.HasLoginForm,
+		Score:        int32(a.Score),
+		AnalyzedAt:   timestamp(a.AnalyzedAt),
+	}
+	for _, tech := range a.Technologies {
+		analysis.Technologies = append(analysis.Technologies, &pb.Technology{Name: tech.Name, Category: tech.Category, Version: tech.Version})
+	}
+	if a.Security != nil {
+		analysis.Security = toSecurity(a.Security)
+	}
+	if a.Resources != nil {
+		analysis.Resources = toResources(a.Resources)
+	}
+	if a.Performance != nil {
+		analysis.Performance = toPerformance(a.Performance)
+	}
+	if a.StructuredData != nil {
+		analysis.StructuredData = toStructuredData(a.StructuredData)
+	}
+	if a.Redirects != nil {
+		analysis.Redirects = &pb.Redirects{
+			FinalUrl:       a.Redirects.FinalURL,
+			Loop:           a.Redirects.Loop,
+			HttpsDowngrade: a.Redirects.HTTPSDowngrade,
+			LongChain:      a.Redirects.LongChain,
+		}
+		for _, hop := range a.Redirects.Chain {
+			analysis.Redirects.Chain = append(analysis.Redirects.Chain, &pb.RedirectHop{
+				Url:        hop.URL,
+				StatusCode: int32(hop.StatusCode),
+				LatencyMs:  hop.LatencyMs,
+			})
+		}
+	}
+	return analysis
+}
+
+func toSecurity(s *v1.Security) *pb.Security {
+	sec := &pb.Security{MixedContent: s.MixedContent}
+	for _, h := range s.Headers {
+		sec.Headers = append(sec.Headers, &pb.SecurityHeader{
+			Name:   h.Name,
+			Value:  h.Value,
+			Status: headerStatuses[h.Status],
+			Issues: h.Issues,
+		})
+	}
+	for _, c := range s.Cookies {
+		sec.Cookies = append(sec.Cookies, &pb.Cookie{
+			Name:     c.Name,
+			Secure:   c.Secure,
+			HttpOnly: c.HTTPOnly,
+			SameSite: c.SameSite,
+			Issues:   c.Issues,
+		})
+	}
+	if s.TLS != nil {
+		sec.Tls = &pb.Tls{
+			Version:            s.TLS.Version,
+			CipherSuite:        s.TLS.CipherSuite,
+			CertificateSubject: s.TLS.CertificateSubject,
+			CertificateIssuer:  s.TLS.CertificateIssuer,
+			CertificateExpiry:  timestamp(&s.TLS.CertificateExpiry),
+			DaysUntilExpiry:    int32(s.TLS.DaysUntilExpiry),
+			Issues:             s.TLS.Issues,
+		}
+	}
+	return sec
+}
+
+func toResources(r *v1.Resources) *pb.Resources {
+	res := &pb.Resources{
+		FirstParty:      int32(r.FirstParty),
+		ThirdParty:      int32(r.ThirdParty),
+		Broken:          int32(r.Broken),
+		InlineScripts:   int32(r.InlineScripts),
+		InlineStyles:    int32(r.InlineStyles),
+		StyleAttributes: int32(r.StyleAttributes),
+	}
+	for _, item := range r.Items {
+		res.Items = append(res.Items, &pb.Resource{
+			Url:        item.URL,
+			Type:       resourceTypes[item.Type],
+			ThirdParty: item.ThirdParty,
+			Broken:     item.Broken,
+		})
+	}
+	return res
+}
+
+func toPerformance(p *v1.Performance) *pb.Performance {
+	return &pb.Performance{
+		DocumentBytes:            p.DocumentBytes,
+		TotalBytes:               p.TotalBytes,
+		BytesByType:              p.BytesByType,
+		Requests:                 int32(p.Requests),
+		Fetched:                  p.Fetched,
+		Truncated:                p.Truncated,
+		RenderBlocking:           p.RenderBlocking,
+		ImagesWithoutDimensions:  p.ImagesWithoutDimensions,
+		ImagesWithoutLazyLoading: p.ImagesWithoutLazyLoading,
+		Uncompressed:             p.Uncompressed,
+		Uncached:                 p.Uncached,
+	}
+}
+
+func toStructuredData(d *v1.StructuredData) *pb.StructuredData {
+	data := &pb.StructuredData{Errors: d.Errors}
+	for _, e := range d.Entities {
+		entity := &pb.Entity{
+			Format:     structuredDataFormats[e.Format],
+			Type:       e.Type,
+			Id:         e.ID,
+			Properties: make(map[string]*pb.PropertyValues, len(e.Properties)),
+			Issues:     e.Issues,
+		}
+		for name, values := range e.Properties {
+			entity.Properties[name] = &pb.PropertyValues{Values: values}
+		}
+		data.Entities = append(data.Entities, entity)
+	}
+	return data
+}
+
+func toJobEvent(e jobs.Event) *pb.JobEvent {
+	return &pb.JobEvent{
+		Seq:        int64(e.Seq),
+		JobId:      e.JobID,
+		Time:       timestamp(&e.Time),
+		Type:       e.Type,
+		Url:        e.URL,
+		StatusCode: int32(e.StatusCode),
+		Link:       e.Link,
+		Broken:     e.Broken,
+		Checked:    int32(e.Checked),
+		Total:      int32(e.Total),
+		Error:      e.Error,
+		Analysis:   toAnalysis(e.Result),
+	}
+}
+
+func toURL(e models.URLEntry) *pb.Url {
+	return &pb.Url{
+		Url:              e.URL,
+		SubmitCount:      int32(e.SubmitCount),
+		FirstSubmittedAt: timestamp(&e.FirstSubmittedAt),
+		LastSubmittedAt:  timestamp(&e.LastSubmittedAt),
+		LastJobId:        e.LastJobID,
+		LastStatus:       analysisStatuses[v1.ParseAnalysisStatus(e.LastStatus)],
+		LastScore:        int32(e.LastScore),
+		LastTitle:        e.LastTitle,
+		LastError:        e.LastError,
+		LastAnalyzedAt:   timestamp(e.LastAnalyzedAt),
+	}
+}
+
+func toCrawlPage(p crawler.Page) *pb.CrawlPage {
+	return &pb.CrawlPage{
+		Url:      p.URL,
+		Depth:    int32(p.Depth),
+		Analysis: toAnalysis(p.Result),
+		Error:    p.Error,
+	}
+}
+
+// timestamp converts a time, leaving nil and zero times unset.
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,30 @@
+package grpcserver
+
+import (
+	"context"
+
+	"web-analyzer/internal/health"
+	pb "web-analyzer/proto/webanalyzer/v1"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthServer reports the readiness checks of /readyz through the gRPC health
+// protocol, for the server as a whole ("") and for AnalyzerService.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	checker *health.Checker
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() != "" && req.GetService() != pb.AnalyzerService_ServiceDesc.ServiceName {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	resp := &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}
+	if s.checker != nil && s.checker.Run(ctx).Status != health.StatusOK {
+		resp.Status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return resp, nil
+}
@@ -0,0 +1,175 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/ratelimit"
+	"web-analyzer/internal/workspace"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// servicePrefix is the prefix of the full method names of AnalyzerService.
+const servicePrefix = "/webanalyzer.v1.AnalyzerService/"
+
+// methodScopes lists the scope each AnalyzerService method requires. Methods
+// of the service missing here require the superadmin scope; other services, such as
+// health and reflection, are public.
+var methodScopes = map[string]auth.Scope{
+	servicePrefix + "Analyze":         auth.ScopeAnalyze,
+	servicePrefix + "Crawl":           auth.ScopeAnalyze,
+	servicePrefix + "GetJob":          auth.ScopeRead,
+	servicePrefix + "StreamJobEvents": auth.ScopeRead,
+	servicePrefix + "ListUrls":        auth.ScopeRead,
+}
+
+// interceptor authenticates and rate limits calls like the REST middleware
+// does, and tags their logger with a request ID.
+type interceptor struct {
+	auth       *auth.Store
+	keyLimiter *ratelimit.Limiter
+	ipLimiter  *ratelimit.Limiter
+}
+
+func (i *interceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := i.prepare(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, start, err)
+	return resp, err
+}
+
+func (i *interceptor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.prepare(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	logCall(ctx, start, err)
+	return err
+}
+
+// prepare returns the context a call is served with, or the status error it
+// is rejected with.
+func (i *interceptor) prepare(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := first(md, strings.ToLower(logging.RequestIDHeader))
+	if id == "" || len(id) > logging.MaxRequestIDLength {
+		id = logging.NewRequestID()
+	}
+	ctx = logging.With(ctx, "request_id", id, "grpc_method", method)
+
+	if i.ipLimiter != nil {
+		if p, ok := peer.FromContext(ctx); ok {
+			if err := allow(i.ipLimiter, "ip:"+hostOf(p.Addr)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !strings.HasPrefix(method, servicePrefix) || i.auth == nil {
+		return ctx, nil
+	}
+	scope, ok := methodScopes[method]
+	if !ok {
+		scope = auth.ScopeSuperAdmin
+	}
+	secret, _ := strings.CutPrefix(first(md, "authorization"), "Bearer ")
+	if secret == "" {
+		secret = first(md, strings.ToLower(auth.APIKeyHeader))
+	}
+	key, ok := i.auth.Authenticate(strings.TrimSpace(secret))
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Missing or invalid API key")
+	}
+	if !key.Allows(scope) {
+		return nil, status.Error(codes.PermissionDenied, "API key lacks the "+string(scope)+" scope")
+	}
+	if i.keyLimiter != nil {
+		if err := allow(i.keyLimiter, "key:"+key.ID); err != nil {
+			return nil, err
+		}
+	}
+	ctx = workspace.WithID(ctx, key.Workspace)
+	return logging.With(ctx, "key_id", key.ID, "workspace", key.Workspace), nil
+}
+
+func allow(l *ratelimit.Limiter, key string) error {
+	if result := l.Allow(key); !result.Allowed {
+		return status.Errorf(codes.ResourceExhausted, "Rate limit exceeded, retry in %s", result.RetryAfter.Round(time.Second))
+	}
+	return nil
+}
+
+// recoverUnary turns a panic of a handler into an Internal error, like
+// logging.Recovery does for REST handlers. It runs inside the interceptor so
+// that the panic is logged with the request ID.
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer recoverCall(ctx, &err)
+	return handler(ctx, req)
+}
+
+func recoverStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverCall(ss.Context(), &err)
+	return handler(srv, ss)
+}
+
+func recoverCall(ctx context.Context, err *error) {
+	if r := recover(); r != nil {
+		logging.FromContext(ctx).Error("Panic recovered", "error", r, "stack", string(debug.Stack()))
+		*err = status.Error(codes.Internal, "Internal error")
+	}
+}
+
+func logCall(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.Canceled:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+	default:
+		level = slog.LevelWarn
+	}
+	logging.FromContext(ctx).Log(ctx, level, "gRPC call", "code", code.String(), "latency", time.Since(start))
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func hostOf(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,201 @@
+// Package grpcserver serves the AnalyzerService gRPC API next to the REST
+// handlers. It shares their job queue, workspaces, API keys and rate limits,
+// and registers the gRPC health and reflection services.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"web-analyzer/handlers"
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/listing"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/workspace"
+	pb "web-analyzer/proto/webanalyzer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// MaxCrawlPages is the largest max_pages accepted by Crawl.
+const MaxCrawlPages = handlers.MaxCrawlPages
+
+// New returns a gRPC server backed by the jobs, workspaces and authentication
+// of h.
+func New(h *handlers.Handler) *grpc.Server {
+	i := &interceptor{auth: h.Auth, keyLimiter: h.KeyLimiter, ipLimiter: h.IPLimiter}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.unary, recoverUnary),
+		grpc.ChainStreamInterceptor(i.stream, recoverStream),
+	)
+	pb.RegisterAnalyzerServiceServer(s, &service{h: h})
+	healthpb.RegisterHealthServer(s, &healthServer{checker: h.Health})
+	reflection.Register(s)
+	return s
+}
+
+// service implements pb.AnalyzerServiceServer.
+type service struct {
+	pb.UnimplementedAnalyzerServiceServer
+	h *handlers.Handler
+}
+
+func (s *service) Analyze(ctx context.Context, req *pb.AnalyzeRequest) (*pb.Job, error) {
+	if msg := handlers.ValidateURL(req.GetUrl()); msg != "" {
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	if req.GetCallbackUrl() != "" {
+		if msg := s.h.ValidateCallbackURL(req.GetCallbackUrl()); msg != "" {
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+	}
+
+	logging.FromContext(ctx).Info("URL submitted for analysis", "url", req.GetUrl())
+	job, err := s.h.Jobs.SubmitWithCallback(ctx, req.GetUrl(), req.GetCallbackUrl())
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to queue analysis", "url", req.GetUrl(), "error", err)
+		if errors.Is(err, workspace.ErrQuotaExceeded) {
+			return nil, status.Error(codes.ResourceExhausted, "Daily analysis quota exceeded")
+		}
+		return nil, status.Error(codes.Unavailable, "Analysis queue is full")
+	}
+	return toJob(job), nil
+}
+
+func (s *service) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.Job, error) {
+	job, err := s.job(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := toJob(job)
+	if job.Result != nil {
+		resp.Analysis = toAnalysis(job.Result)
+	}
+	return resp, nil
+}
+
+func (s *service) StreamJobEvents(req *pb.StreamJobEventsRequest, stream grpc.ServerStreamingServer[pb.JobEvent]) error {
+	ctx := stream.Context()
+	if _, err := s.job(ctx, req.GetId()); err != nil {
+		return err
+	}
+	sub, ok := s.h.Jobs.Subscribe(req.GetId())
+	if !ok {
+		return status.Error(codes.NotFound, "Job not found")
+	}
+	defer sub.Close()
+
+	lastSeq := req.GetAfterSeq()
+	send := func(event jobs.Event) error {
+		if int64(event.Seq) <= lastSeq {
+			return nil
+		}
+		if err := stream.Send(toJobEvent(event)); err != nil {
+			return err
+		}
+		lastSeq = int64(event.Seq)
+		return nil
+	}
+	for _, event := range sub.Past {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, open := <-sub.Events:
+			if !open {
+				if sub.Dropped() {
+					return status.Errorf(codes.Aborted, "Stream fell behind, resume with after_seq %d", lastSeq)
+				}
+				return nil
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *service) ListUrls(ctx context.Context, req *pb.ListUrlsRequest) (*pb.ListUrlsResponse, error) {
+	sortBy, err := listing.ParseSort(req.GetSort())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid sort")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > listing.MaxLimit {
+		return nil, status.Error(codes.InvalidArgument, "Invalid limit")
+	}
+	query := listing.Query{
+		Host:      req.GetHost(),
+		Status:    legacyStatus(req.GetStatus()),
+		Prefix:    req.GetPrefix(),
+		Search:    req.GetQuery(),
+		Sort:      sortBy,
+		Ascending: req.GetAscending(),
+		Limit:     int(req.GetLimit()),
+		Cursor:    req.GetCursor(),
+	}
+	page, err := query.Apply(s.service(ctx).ListUrls())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+	}
+	resp := &pb.ListUrlsResponse{Total: int32(page.Total), NextCursor: page.NextCursor}
+	for _, entry := range page.URLs {
+		resp.Urls = append(resp.Urls, toURL(entry))
+	}
+	return resp, nil
+}
+
+func (s *service) Crawl(req *pb.CrawlRequest, stream grpc.ServerStreamingServer[pb.CrawlPage]) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	opts := crawler.Options{MaxPages: int(req.GetMaxPages()), MaxDepth: int(req.GetMaxDepth())}
+	if opts.MaxPages > MaxCrawlPages {
+		return status.Errorf(codes.InvalidArgument, "max_pages must be at most %d", MaxCrawlPages)
+	}
+
+	var sendErr error
+	_, err := s.h.Crawl(ctx, req.GetUrl(), opts, func(page crawler.Page) {
+		if sendErr != nil {
+			return
+		}
+		if sendErr = stream.Send(toCrawlPage(page)); sendErr != nil {
+			cancel()
+		}
+	})
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case errors.Is(err, workspace.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, crawler.ErrInvalidURL):
+		return status.Error(codes.InvalidArgument, "url must be an absolute http(s) URL")
+	case err != nil:
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// job returns the job with the given ID if it belongs to the caller's
+// workspace.
+func (s *service) job(ctx context.Context, id string) (jobs.Job, error) {
+	job, ok := s.h.Jobs.Job(id)
+	if !ok || job.Workspace != workspace.FromContext(ctx) {
+		return jobs.Job{}, status.Error(codes.NotFound, "Job not found")
+	}
+	return job, nil
+}
+
+// service returns the analyzer service of the caller's workspace.
+func (s *service) service(ctx context.Context) analyzer.AnalyzerService {
+	return s.h.Workspaces.Service(workspace.FromContext(ctx))
+}
@@ -0,0 +1,245 @@
+package grpcserver_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"web-analyzer/handlers"
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/grpcserver"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+	pb "web-analyzer/proto/webanalyzer/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// siteService serves a fixed link graph and records the analysed URLs.
+type siteService struct {
+	mu       sync.Mutex
+	links    map[string][]string
+	analysed []string
+}
+
+func newSite() *siteService {
+	return &siteService{links: map[string][]string{
+		"https://example.com/":  {"https://example.com/a", "https://example.com/b"},
+		"https://example.com/a": nil,
+		"https://example.com/b": nil,
+	}}
+}
+
+func (s *siteService) AnalyzePage(ctx context.Context, url string) error {
+	s.mu.Lock()
+	s.analysed = append(s.analysed, url)
+	s.mu.Unlock()
+	result, _ := s.GetAnalysis(url)
+	analyzer.ReportResult(ctx, result, nil)
+	return nil
+}
+
+func (s *siteService) GetAnalysis(url string) (models.AnalysisResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, analysed := range s.analysed {
+		if analysed == url {
+			return models.AnalysisResult{URL: url, Status: "Completed", Title: "Example", Links: s.links[url]}, true
+		}
+	}
+	return models.AnalysisResult{}, false
+}
+
+func (s *siteService) ListAnalyses() []models.AnalysisResult { return nil }
+
+func (s *siteService) GetHistory(url string) []models.Snapshot { return nil }
+
+func (s *siteService) GetSubmittedUrls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.analysed...)
+}
+
+func (s *siteService) ListUrls() []models.URLEntry {
+	var entries []models.URLEntry
+	for _, url := range s.GetSubmittedUrls() {
+		entries = append(entries, models.URLEntry{URL: url, SubmitCount: 1, LastStatus: "Completed"})
+	}
+	return entries
+}
+
+func (s *siteService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	return models.AnalysisResult{}, nil
+}
+
+// dial serves h over an in-memory listener and returns a connected client.
+func dial(t *testing.T, h *handlers.Handler) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpcserver.New(h)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+}
+
+func TestAnalyzeAndGetJob(t *testing.T) {
+	site := newSite()
+	client := pb.NewAnalyzerServiceClient(dial(t, handlers.NewHandler(site)))
+	ctx := context.Background()
+
+	_, err := client.Analyze(ctx, &pb.AnalyzeRequest{Url: "not a url"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	job, err := client.Analyze(ctx, &pb.AnalyzeRequest{Url: "https://example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/", job.GetUrl())
+	assert.Equal(t, workspace.Default, job.GetWorkspace())
+
+	assert.Eventually(t, func() bool {
+		got, err := client.GetJob(ctx, &pb.GetJobRequest{Id: job.GetId()})
+		return err == nil && got.GetStatus() == pb.JobStatus_JOB_STATUS_COMPLETED && got.GetAnalysis().GetTitle() == "Example"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	_, err = client.GetJob(ctx, &pb.GetJobRequest{Id: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	urls, err := client.ListUrls(ctx, &pb.ListUrlsRequest{Status: pb.AnalysisStatus_ANALYSIS_STATUS_COMPLETED})
+	require.NoError(t, err)
+	require.Len(t, urls.GetUrls(), 1)
+	assert.Equal(t, pb.AnalysisStatus_ANALYSIS_STATUS_COMPLETED, urls.GetUrls()[0].GetLastStatus())
+
+	_, err = client.ListUrls(ctx, &pb.ListUrlsRequest{Cursor: "bogus"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamJobEvents(t *testing.T) {
+	client := pb.NewAnalyzerServiceClient(dial(t, handlers.NewHandler(newSite())))
+	ctx := context.Background()
+
+	job, err := client.Analyze(ctx, &pb.AnalyzeRequest{Url: "https://example.com/"})
+	require.NoError(t, err)
+	stream, err := client.StreamJobEvents(ctx, &pb.StreamJobEventsRequest{Id: job.GetId()})
+	require.NoError(t, err)
+
+	var last *pb.JobEvent
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, job.GetId(), event.GetJobId())
+		last = event
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, "completed", last.GetType())
+}
+
+func TestCrawlStreamsPages(t *testing.T) {
+	site := newSite()
+	client := pb.NewAnalyzerServiceClient(dial(t, handlers.NewHandler(site)))
+
+	stream, err := client.Crawl(context.Background(), &pb.CrawlRequest{Url: "https://example.com/", MaxPages: 10})
+	require.NoError(t, err)
+	var urls []string
+	for {
+		page, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		urls = append(urls, page.GetUrl())
+	}
+	assert.ElementsMatch(t, []string{"https://example.com/", "https://example.com/a", "https://example.com/b"}, urls)
+
+	stream, err = client.Crawl(context.Background(), &pb.CrawlRequest{Url: "https://example.com/", MaxPages: grpcserver.MaxCrawlPages + 1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAuthentication(t *testing.T) {
+	h := handlers.NewWorkspaceHandler(workspace.NewRegistry(func(string) analyzer.AnalyzerService {
+		return newSite()
+	}, workspace.Quota{}))
+	h.Auth, _ = auth.NewStore("")
+	_, readKey, _ := h.Auth.Issue("reader", "team-a", []auth.Scope{auth.ScopeRead})
+	conn := dial(t, h)
+	client := pb.NewAnalyzerServiceClient(conn)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"missing key", context.Background(), codes.Unauthenticated},
+		{"invalid key", withKey("wa_invalid"), codes.Unauthenticated},
+		{"read scope", withKey(readKey), codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ListUrls(tt.ctx, &pb.ListUrlsRequest{})
+			assert.Equal(t, tt.want, status.Code(err))
+		})
+	}
+
+	_, err := client.Analyze(withKey(readKey), &pb.AnalyzeRequest{Url: "https://example.com/"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Health checks stay public.
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+// panicService panics while listing URLs.
+type panicService struct{ *siteService }
+
+func (panicService) ListUrls() []models.URLEntry { panic("boom") }
+
+func TestRecoversFromPanics(t *testing.T) {
+	client := pb.NewAnalyzerServiceClient(dial(t, handlers.NewHandler(panicService{newSite()})))
+
+	_, err := client.ListUrls(context.Background(), &pb.ListUrlsRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// The server keeps serving.
+	_, err = client.Analyze(context.Background(), &pb.AnalyzeRequest{Url: "https://example.com/"})
+	assert.NoError(t, err)
+}
+
+func TestHealthReportsReadiness(t *testing.T) {
+	h := handlers.NewHandler(newSite())
+	h.Health.Add("storage", func(context.Context) error { return io.ErrUnexpectedEOF })
+	client := healthpb.NewHealthClient(dial(t, h))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "webanalyzer.v1.AnalyzerService"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestChecker_Run(t *testing.T) {
+	c := NewChecker()
+	c.Add("storage", func(ctx context.Context) error { return nil })
+	report := c.Run(context.Background())
+	if report.Status != StatusOK || report.Checks["storage"].Status != StatusOK {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	c.Add("queue", func(ctx context.Context) error { return errors.New("saturated") })
+	report = c.Run(context.Background())
+	if report.Status != StatusUnavailable {
+		t.Errorf("expected unavailable, got %+v", report)
+	}
+	if got := report.Checks["queue"]; got.Status != StatusUnavailable || got.Error != "saturated" {
+		t.Errorf("unexpected queue result: %+v", got)
+	}
+	if report.Checks["storage"].Status != StatusOK {
+		t.Errorf("expected storage to stay ok, got %+v", report.Checks["storage"])
+	}
+}
+
+func TestRunCheck_Timeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	result := runCheck(ctx, func(context.Context) error {
+		<-block
+		return nil
+	})
+	if result.Status != StatusUnavailable {
+		t.Errorf("expected a hung check to fail, got %+v", result)
+	}
+}
+
+func TestWritable(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	if err := Writable(dir)(context.Background()); err != nil {
+		t.Fatalf("expected a writable directory, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("expected the probe file to be removed, found %v", entries)
+	}
+
+	// A regular file cannot hold other files.
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Writable(file)(context.Background()); err == nil {
+		t.Error("expected a file to fail the check")
+	}
+}
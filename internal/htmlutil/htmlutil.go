@@ -0,0 +1,31 @@
+// Package htmlutil provides helpers shared by the packages that inspect parsed
+// HTML documents.
+package htmlutil
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Attr returns the value of the attribute key of n, and whether n has it.
+func Attr(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// HasToken reports whether the space separated list, such as the value of a
+// rel or class attribute, contains token. Tokens are compared case
+// insensitively.
+func HasToken(list, token string) bool {
+	for _, t := range strings.Fields(list) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestAttr(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<a href="/x" download>link</a>`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a := doc.FirstChild.LastChild.FirstChild // html > body > a
+
+	if v, ok := Attr(a, "href"); !ok || v != "/x" {
+		t.Errorf(`Attr(href) = %q, %v; want "/x", true`, v, ok)
+	}
+	if v, ok := Attr(a, "download"); !ok || v != "" {
+		t.Errorf(`Attr(download) = %q, %v; want "", true`, v, ok)
+	}
+	if _, ok := Attr(a, "rel"); ok {
+		t.Error("expected no rel attribute")
+	}
+}
+
+func TestHasToken(t *testing.T) {
+	tests := []struct {
+		list, token string
+		want        bool
+	}{
+		{"stylesheet", "stylesheet", true},
+		{" Alternate  StyleSheet ", "stylesheet", true},
+		{"preload", "stylesheet", false},
+		{"stylesheets", "stylesheet", false},
+		{"", "stylesheet", false},
+	}
+	for _, tt := range tests {
+		if got := HasToken(tt.list, tt.token); got != tt.want {
+			t.Errorf("HasToken(%q, %q) = %v; want %v", tt.list, tt.token, got, tt.want)
+		}
+	}
+}
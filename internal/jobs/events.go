@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"time"
+
+	"web-analyzer/internal/analyzer"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber. Subscribers
+// that fall further behind are disconnected and can resume from the last event
+// they received.
+const subscriberBuffer = 256
+
+// Event is a progress event of a job.
+type Event struct {
+	Seq   int       `json:"seq"`
+	JobID string    `json:"job_id"`
+	Time  time.Time `json:"time"`
+	analyzer.ProgressEvent
+}
+
+// eventStream keeps the events of a job and fans them out to subscribers.
+type eventStream struct {
+	events      []Event
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Subscription receives the events of a job.
+type Subscription struct {
+	// Past holds the events published before the subscription.
+	Past []Event
+	// Events delivers the following events. It is closed when the job
+	// finishes, the subscriber falls behind or Close is called.
+	Events <-chan Event
+
+	m       *Manager
+	stream  *eventStream
+	ch      chan Event
+	dropped bool
+}
+
+// Close stops receiving events. It must be called once the subscriber is done.
+func (s *Subscription) Close() {
+	s.m.eventsMu.Lock()
+	defer s.m.eventsMu.Unlock()
+	if _, ok := s.stream.subscribers[s]; ok {
+		delete(s.stream.subscribers, s)
+		close(s.ch)
+	}
+}
+
+// Dropped reports whether Events was closed because the subscriber fell behind
+// rather than because the job finished. Subscribers can then resume after the
+// last event they received.
+func (s *Subscription) Dropped() bool {
+	s.m.eventsMu.Lock()
+	defer s.m.eventsMu.Unlock()
+	return s.dropped
+}
+
+// Subscribe returns the events published so far for the job and subscribes to
+// the following ones.
+func (m *Manager) Subscribe(jobID string) (*Subscription, bool) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	stream, ok := m.streams[jobID]
+	if !ok {
+		return nil, false
+	}
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{
+		Past:   make([]Event, len(stream.events)),
+		Events: ch,
+		m:      m,
+		stream: stream,
+		ch:     ch,
+	}
+	copy(sub.Past, stream.events)
+
+	if stream.closed {
+		close(ch)
+		return sub, true
+	}
+	stream.subscribers[sub] = struct{}{}
+	return sub, true
+}
+
+func (m *Manager) openStream(jobID string) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+	m.streams[jobID] = &eventStream{subscribers: make(map[*Subscription]struct{})}
+}
+
+func (m *Manager) publish(jobID string, progress analyzer.ProgressEvent) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	stream, ok := m.streams[jobID]
+	if !ok || stream.closed {
+		return
+	}
+	event := Event{
+		Seq:           len(stream.events) + 1,
+		JobID:         jobID,
+		Time:          time.Now(),
+		ProgressEvent: progress,
+	}
+	stream.events = append(stream.events, event)
+	for sub := range stream.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			delete(stream.subscribers, sub)
+			sub.dropped = true
+			close(sub.ch)
+		}
+	}
+}
+
+func (m *Manager) closeStream(jobID string) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+
+	stream, ok := m.streams[jobID]
+	if !ok || stream.closed {
+		return
+	}
+	stream.closed = true
+	for sub := range stream.subscribers {
+		delete(stream.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// removeStreams drops the events of finished jobs.
+func (m *Manager) removeStreams(jobIDs []string) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+	for _, id := range jobIDs {
+		delete(m.streams, id)
+	}
+}
@@ -0,0 +1,104 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+)
+
+type progressService struct {
+	mockAnalyzerService
+	release chan struct{}
+}
+
+func (p *progressService) AnalyzePage(ctx context.Context, url string) error {
+	<-p.release
+	result, _ := p.GetAnalysis(url)
+	analyzer.ReportResult(ctx, result, nil)
+	return nil
+}
+
+func (p *progressService) GetAnalysis(url string) (models.AnalysisResult, bool) {
+	return models.AnalysisResult{URL: url, Status: "Completed"}, true
+}
+
+func TestManager_SubscribeReceivesFinalEvent(t *testing.T) {
+	service := &progressService{release: make(chan struct{})}
+	m := NewManager(workspace.Static(service), 1, 10)
+
+	job, err := m.Submit(context.Background(), "http://a.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.publish(job.ID, analyzer.ProgressEvent{Type: analyzer.EventFetchStarted})
+
+	sub, ok := m.Subscribe(job.ID)
+	if !ok {
+		t.Fatal("expected job stream to exist")
+	}
+	defer sub.Close()
+	if len(sub.Past) != 1 || sub.Past[0].Seq != 1 {
+		t.Fatalf("expected replay of published event, got %+v", sub.Past)
+	}
+
+	close(service.release)
+
+	var last Event
+	for event := range sub.Events {
+		last = event
+	}
+	if last.Type != analyzer.EventCompleted || last.Result == nil {
+		t.Errorf("expected completed event with result, got %+v", last)
+	}
+	if sub.Dropped() {
+		t.Error("expected finished stream not to count as dropped")
+	}
+
+	finished, _ := m.Subscribe(job.ID)
+	if _, open := <-finished.Events; open {
+		t.Error("expected channel of finished job to be closed")
+	}
+	if len(finished.Past) != 2 {
+		t.Errorf("expected 2 events after completion, got %d", len(finished.Past))
+	}
+}
+
+func TestManager_SubscribeUnknownJob(t *testing.T) {
+	m := NewManager(workspace.Static(&mockAnalyzerService{}), 1, 1)
+	if _, ok := m.Subscribe("missing"); ok {
+		t.Error("expected unknown job to have no stream")
+	}
+}
+
+func TestManager_SubscribeReportsDroppedSubscriber(t *testing.T) {
+	service := &progressService{release: make(chan struct{})}
+	defer close(service.release)
+	m := NewManager(workspace.Static(service), 1, 10)
+
+	job, err := m.Submit(context.Background(), "http://a.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub, ok := m.Subscribe(job.ID)
+	if !ok {
+		t.Fatal("expected job stream to exist")
+	}
+	defer sub.Close()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		m.publish(job.ID, analyzer.ProgressEvent{Type: analyzer.EventLinkChecked})
+	}
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("expected %d buffered events, got %d", subscriberBuffer, received)
+	}
+	if !sub.Dropped() {
+		t.Error("expected lagging subscriber to be reported as dropped")
+	}
+}
@@ -0,0 +1,496 @@
+// Package jobs queues URL analyses and tracks their progress, individually and
+// as batches.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/metrics"
+	"web-analyzer/internal/tracing"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusQueued    Status = "Queued"
+	StatusRunning   Status = "In progress"
+	StatusCompleted Status = "Completed"
+	StatusFailed    Status = "Failed"
+)
+
+const (
+	// DefaultWorkers is the number of analyses run concurrently.
+	DefaultWorkers = 4
+	// DefaultQueueSize is the number of jobs that can wait for a worker.
+	DefaultQueueSize = 1000
+	// DefaultRetention is how long finished jobs, their events and their
+	// batches are kept.
+	DefaultRetention = time.Hour
+
+	// saturationRatio is the share of the queue above which it is reported as
+	// saturated.
+	saturationRatio = 0.9
+	// StuckAfter is how long a worker may spend on a single job before it is
+	// considered stuck.
+	StuckAfter = 5 * time.Minute
+)
+
+var (
+	// ErrQueueFull is returned when a job cannot be queued because the queue is
+	// at capacity.
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrQueueSaturated is reported when the queue is nearly full.
+	ErrQueueSaturated = errors.New("job queue is saturated")
+	// ErrWorkersStuck is reported when every worker is stuck on a job.
+	ErrWorkersStuck = errors.New("all workers are stuck")
+)
+
+// Job is a single analysis of a URL.
+type Job struct {
+	ID        string `json:"job_id"`
+	Workspace string `json:"workspace"`
+	URL       string `json:"url"`
+	BatchID   string `json:"batch_id,omitempty"`
+	// CallbackURL receives a webhook notification when the job finishes.
+	CallbackURL string     `json:"callback_url,omitempty"`
+	Status      Status     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	// Result is the result of a completed job, as reported by the analyzer
+	// service, and PreviousScore the score of the URL's snapshot before it.
+	Result        *models.AnalysisResult `json:"-"`
+	PreviousScore *int                   `json:"-"`
+
+	// submitter is the span of the request that queued the job. The analysis
+	// span links to it since it runs after the request has finished.
+	submitter trace.SpanContext
+	// logger is the logger of the request that queued the job, so the analysis
+	// logs with its request ID.
+	logger *slog.Logger
+}
+
+// Batch groups jobs submitted together.
+type Batch struct {
+	ID        string    `json:"batch_id"`
+	Workspace string    `json:"workspace"`
+	JobIDs    []string  `json:"job_ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchProgress is the aggregate state of the jobs of a batch.
+type BatchProgress struct {
+	ID        string    `json:"batch_id"`
+	Workspace string    `json:"workspace"`
+	CreatedAt time.Time `json:"created_at"`
+	Total     int       `json:"total"`
+	Queued    int       `json:"queued"`
+	Running   int       `json:"running"`
+	Completed int       `json:"completed"`
+	Failed    int       `json:"failed"`
+	Done      bool      `json:"done"`
+	Jobs      []Job     `json:"jobs"`
+}
+
+// WorkerStats describes the state of the worker pool.
+type WorkerStats struct {
+	Workers int `json:"workers"`
+	Busy    int `json:"busy"`
+	Stuck   int `json:"stuck"`
+}
+
+// Manager runs analyses on a fixed pool of workers. Jobs run against the
+// analyzer service of their workspace.
+type Manager struct {
+	// Retention is how long a job is kept once it has finished. A batch is
+	// kept until all of its jobs have expired. Expired jobs are removed as new
+	// jobs and batches are submitted; a non-positive Retention keeps them
+	// forever.
+	Retention time.Duration
+
+	workspaces *workspace.Registry
+	queue      chan *Job
+
+	mu        sync.RWMutex
+	jobs      map[string]*Job
+	batches   map[string]*Batch
+	listeners []func(Job)
+	// busySince holds, per worker, when its current job started. The zero time
+	// means the worker is idle.
+	busySince []time.Time
+	// expired is when expired jobs were last removed.
+	expired time.Time
+
+	eventsMu sync.Mutex
+	streams  map[string]*eventStream
+}
+
+// NewManager creates a Manager and starts its workers.
+func NewManager(workspaces *workspace.Registry, workers, queueSize int) *Manager {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	m := &Manager{
+		Retention:  DefaultRetention,
+		workspaces: workspaces,
+		queue:      make(chan *Job, queueSize),
+		jobs:       make(map[string]*Job),
+		batches:    make(map[string]*Batch),
+		streams:    make(map[string]*eventStream),
+		busySince:  make([]time.Time, workers),
+	}
+	for i := 0; i < workers; i++ {
+		go m.worker(i)
+	}
+	return m
+}
+
+// Submit queues an analysis of url in the workspace of ctx. The span carried by
+// ctx, if any, is linked from the analysis span. It returns an error wrapping
+// workspace.ErrQuotaExceeded when the workspace has no analyses left today.
+func (m *Manager) Submit(ctx context.Context, url string) (Job, error) {
+	return m.submit(ctx, &Job{URL: url})
+}
+
+// SubmitWithCallback queues an analysis of url whose outcome is posted to
+// callbackURL.
+func (m *Manager) SubmitWithCallback(ctx context.Context, url, callbackURL string) (Job, error) {
+	return m.submit(ctx, &Job{URL: url, CallbackURL: callbackURL})
+}
+
+// OnFinish registers fn to be called with the final state of every job once it
+// completes or fails.
+func (m *Manager) OnFinish(fn func(Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// NewBatch registers an empty batch in the workspace of ctx that jobs can be
+// added to with SubmitToBatch.
+func (m *Manager) NewBatch(ctx context.Context) Batch {
+	batch := &Batch{ID: newID(), Workspace: workspace.FromContext(ctx), CreatedAt: time.Now()}
+	m.mu.Lock()
+	m.expire(batch.CreatedAt)
+	m.batches[batch.ID] = batch
+	m.mu.Unlock()
+	return *batch
+}
+
+// SubmitToBatch queues an analysis of url as part of the given batch.
+func (m *Manager) SubmitToBatch(ctx context.Context, batchID, url string) (Job, error) {
+	return m.submit(ctx, &Job{URL: url, BatchID: batchID})
+}
+
+func (m *Manager) submit(ctx context.Context, job *Job) (Job, error) {
+	job.ID = newID()
+	job.Workspace = workspace.FromContext(ctx)
+	job.Status = StatusQueued
+	job.CreatedAt = time.Now()
+	span := trace.SpanFromContext(ctx)
+	job.submitter = span.SpanContext()
+	job.logger = logging.FromContext(ctx).With("job_id", job.ID)
+	if err := m.workspaces.ReserveAnalysis(job.Workspace); err != nil {
+		return Job{}, err
+	}
+	span.AddEvent("job queued", trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("url.full", job.URL),
+	))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expire(job.CreatedAt)
+	select {
+	case m.queue <- job:
+	default:
+		m.workspaces.ReleaseAnalysis(job.Workspace)
+		return Job{}, ErrQueueFull
+	}
+	m.openStream(job.ID)
+	m.jobs[job.ID] = job
+	if batch, ok := m.batches[job.BatchID]; ok {
+		batch.JobIDs = append(batch.JobIDs, job.ID)
+	}
+	return *job, nil
+}
+
+// expire removes the jobs that finished more than Retention before now, with
+// their events, and the batches all of whose jobs did. It runs at most once
+// per Retention, or per minute when that is longer. m.mu must be held.
+func (m *Manager) expire(now time.Time) {
+	if m.Retention <= 0 || now.Sub(m.expired) < min(m.Retention, time.Minute) {
+		return
+	}
+	m.expired = now
+	cutoff := now.Add(-m.Retention)
+	finished := func(job *Job) bool {
+		return job.FinishedAt != nil && job.FinishedAt.Before(cutoff)
+	}
+
+	var expired []string
+	for id, batch := range m.batches {
+		if batch.CreatedAt.After(cutoff) {
+			continue
+		}
+		done := true
+		for _, jobID := range batch.JobIDs {
+			if job, ok := m.jobs[jobID]; ok && !finished(job) {
+				done = false
+				break
+			}
+		}
+		if done {
+			delete(m.batches, id)
+			expired = append(expired, batch.JobIDs...)
+		}
+	}
+	for id, job := range m.jobs {
+		// Jobs of a batch expire with it.
+		if _, ok := m.batches[job.BatchID]; !ok && finished(job) {
+			expired = append(expired, id)
+		}
+	}
+	for _, id := range expired {
+		delete(m.jobs, id)
+	}
+	m.removeStreams(expired)
+}
+
+// Job returns a snapshot of the job with the given ID.
+func (m *Manager) Job(id string) (Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// List returns the most recent jobs of a workspace, newest first, at most
+// limit of them when limit is positive.
+func (m *Manager) List(workspaceID string, limit int) []Job {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var list []Job
+	for _, job := range m.jobs {
+		if job.Workspace == workspaceID {
+			list = append(list, *job)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// BatchProgress returns the aggregate progress of the batch with the given ID.
+func (m *Manager) BatchProgress(id string) (BatchProgress, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	batch, ok := m.batches[id]
+	if !ok {
+		return BatchProgress{}, false
+	}
+
+	progress := BatchProgress{
+		ID:        batch.ID,
+		Workspace: batch.Workspace,
+		CreatedAt: batch.CreatedAt,
+		Total:     len(batch.JobIDs),
+		Jobs:      make([]Job, 0, len(batch.JobIDs)),
+	}
+	for _, jobID := range batch.JobIDs {
+		job, ok := m.jobs[jobID]
+		if !ok {
+			continue
+		}
+		switch job.Status {
+		case StatusQueued:
+			progress.Queued++
+		case StatusRunning:
+			progress.Running++
+		case StatusCompleted:
+			progress.Completed++
+		case StatusFailed:
+			progress.Failed++
+		}
+		progress.Jobs = append(progress.Jobs, *job)
+	}
+	progress.Done = progress.Completed+progress.Failed == progress.Total
+	return progress, true
+}
+
+// QueueDepth returns the number of jobs waiting for a worker.
+func (m *Manager) QueueDepth() int {
+	return len(m.queue)
+}
+
+// QueueCapacity returns the number of jobs that can wait for a worker.
+func (m *Manager) QueueCapacity() int {
+	return cap(m.queue)
+}
+
+// WorkerStats reports how many workers are busy, and how many have been running
+// the same job for longer than stuckAfter.
+func (m *Manager) WorkerStats(stuckAfter time.Duration) WorkerStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stats := WorkerStats{Workers: len(m.busySince)}
+	now := time.Now()
+	for _, since := range m.busySince {
+		if since.IsZero() {
+			continue
+		}
+		stats.Busy++
+		if now.Sub(since) > stuckAfter {
+			stats.Stuck++
+		}
+	}
+	return stats
+}
+
+// CheckQueue reports ErrQueueSaturated when the queue is at least 90% full. It
+// has the signature of a health check.
+func (m *Manager) CheckQueue(ctx context.Context) error {
+	depth, capacity := m.QueueDepth(), m.QueueCapacity()
+	if float64(depth) >= saturationRatio*float64(capacity) {
+		return fmt.Errorf("%w: %d of %d slots used", ErrQueueSaturated, depth, capacity)
+	}
+	return nil
+}
+
+// CheckWorkers reports ErrWorkersStuck when every worker has been running the
+// same job for longer than StuckAfter. It has the signature of a health check.
+func (m *Manager) CheckWorkers(ctx context.Context) error {
+	stats := m.WorkerStats(StuckAfter)
+	if stats.Workers == 0 || stats.Stuck == stats.Workers {
+		return fmt.Errorf("%w: %d of %d workers", ErrWorkersStuck, stats.Stuck, stats.Workers)
+	}
+	return nil
+}
+
+func (m *Manager) worker(i int) {
+	for job := range m.queue {
+		m.setBusy(i, time.Now())
+		m.run(job)
+		m.setBusy(i, time.Time{})
+	}
+}
+
+func (m *Manager) setBusy(worker int, since time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.busySince[worker] = since
+}
+
+func (m *Manager) run(job *Job) {
+	m.update(job, func(j *Job) {
+		now := time.Now()
+		j.Status = StatusRunning
+		j.StartedAt = &now
+	})
+
+	metrics.AnalysesStarted.Inc()
+	opts := []trace.SpanStartOption{trace.WithAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("url.full", job.URL),
+	)}
+	if job.submitter.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: job.submitter}))
+	}
+	ctx, span := tracing.Tracer().Start(context.Background(), "analysis", opts...)
+	ctx = workspace.WithID(ctx, job.Workspace)
+	ctx = analyzer.WithJobID(ctx, job.ID)
+	ctx = logging.WithLogger(ctx, job.logger)
+	service := m.workspaces.Service(job.Workspace)
+	ctx = analyzer.WithProgress(ctx, func(event analyzer.ProgressEvent) {
+		m.publish(job.ID, event)
+	})
+	var result *models.AnalysisResult
+	var previousScore *int
+	ctx = analyzer.WithResult(ctx, func(r models.AnalysisResult, previous *models.Snapshot) {
+		result = &r
+		if previous != nil {
+			previousScore = &previous.Result.Score
+		}
+	})
+	err := service.AnalyzePage(ctx, job.URL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, analyzer.FailureReason(err))
+	}
+	span.End()
+
+	m.update(job, func(j *Job) {
+		now := time.Now()
+		j.FinishedAt = &now
+		if err != nil {
+			j.Status = StatusFailed
+			j.Error = err.Error()
+			return
+		}
+		j.Status = StatusCompleted
+		j.Result = result
+		j.PreviousScore = previousScore
+	})
+	if err != nil {
+		metrics.AnalysesFailed.WithLabelValues(analyzer.FailureReason(err)).Inc()
+		job.logger.Warn("Analysis job failed", "url", job.URL, "error", err)
+		m.publish(job.ID, analyzer.ProgressEvent{Type: analyzer.EventFailed, URL: job.URL, Error: err.Error()})
+	} else {
+		metrics.AnalysesCompleted.Inc()
+		m.publish(job.ID, analyzer.ProgressEvent{Type: analyzer.EventCompleted, URL: job.URL, Result: result})
+	}
+	m.closeStream(job.ID)
+
+	m.mu.RLock()
+	final := *job
+	listeners := m.listeners
+	m.mu.RUnlock()
+	for _, fn := range listeners {
+		fn(final)
+	}
+}
+
+func (m *Manager) update(job *Job, fn func(*Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(job)
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,330 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type mockAnalyzerService struct {
+	mu     sync.Mutex
+	failOn map[string]bool
+	block  chan struct{}
+}
+
+func (m *mockAnalyzerService) AnalyzePage(ctx context.Context, url string) error {
+	if m.block != nil {
+		<-m.block
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failOn[url] {
+		return errors.New("fetch failed")
+	}
+	return nil
+}
+
+func (m *mockAnalyzerService) GetAnalysis(url string) (models.AnalysisResult, bool) {
+	return models.AnalysisResult{}, false
+}
+
+func (m *mockAnalyzerService) ListAnalyses() []models.AnalysisResult {
+	return nil
+}
+
+func (m *mockAnalyzerService) GetHistory(url string) []models.Snapshot {
+	return nil
+}
+
+func (m *mockAnalyzerService) GetSubmittedUrls() []string {
+	return nil
+}
+
+func (m *mockAnalyzerService) ListUrls() []models.URLEntry {
+	return nil
+}
+
+func (m *mockAnalyzerService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	return models.AnalysisResult{}, nil
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestManager_BatchProgress(t *testing.T) {
+	service := &mockAnalyzerService{failOn: map[string]bool{"http://bad.com": true}}
+	m := NewManager(workspace.Static(service), 2, 10)
+
+	batch := m.NewBatch(context.Background())
+	for _, url := range []string{"http://a.com", "http://b.com", "http://bad.com"} {
+		if _, err := m.SubmitToBatch(context.Background(), batch.ID, url); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	waitFor(t, func() bool {
+		p, _ := m.BatchProgress(batch.ID)
+		return p.Done
+	})
+
+	progress, ok := m.BatchProgress(batch.ID)
+	if !ok {
+		t.Fatal("expected batch to exist")
+	}
+	if progress.Total != 3 || progress.Completed != 2 || progress.Failed != 1 {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+	for _, job := range progress.Jobs {
+		if job.URL == "http://bad.com" && job.Error != "fetch failed" {
+			t.Errorf("expected failure reason to be recorded, got %q", job.Error)
+		}
+	}
+}
+
+func TestManager_QueueFull(t *testing.T) {
+	service := &mockAnalyzerService{block: make(chan struct{})}
+	defer close(service.block)
+	m := NewManager(workspace.Static(service), 1, 1)
+
+	if _, err := m.Submit(context.Background(), "http://a.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool { return m.QueueDepth() == 0 })
+	if _, err := m.Submit(context.Background(), "http://b.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := m.Submit(context.Background(), "http://c.com"); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+func TestManager_WorkspaceQuota(t *testing.T) {
+	registry := workspace.NewRegistry(func(string) analyzer.AnalyzerService {
+		return &mockAnalyzerService{}
+	}, workspace.Quota{AnalysesPerDay: 1})
+	m := NewManager(registry, 1, 10)
+	ctx := workspace.WithID(context.Background(), "team-a")
+
+	job, err := m.Submit(ctx, "http://a.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Workspace != "team-a" {
+		t.Errorf("expected job in team-a, got %q", job.Workspace)
+	}
+	if _, err := m.Submit(ctx, "http://b.com"); !errors.Is(err, workspace.ErrQuotaExceeded) {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if _, err := m.Submit(context.Background(), "http://b.com"); err != nil {
+		t.Errorf("expected the default workspace to have its own quota, got %v", err)
+	}
+}
+
+func TestManager_LinksAnalysisSpanToSubmitter(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	m := NewManager(workspace.Static(&mockAnalyzerService{}), 1, 1)
+	ctx, request := provider.Tracer("test").Start(context.Background(), "request")
+	job, err := m.Submit(ctx, "http://a.com")
+	request.End()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool {
+		j, _ := m.Job(job.ID)
+		return j.Status == StatusCompleted
+	})
+
+	// Jobs left over by other tests may end their spans on this provider too.
+	var analysis sdktrace.ReadOnlySpan
+	waitFor(t, func() bool {
+		for _, span := range recorder.Ended() {
+			if span.Name() == "analysis" && slices.Contains(span.Attributes(), attribute.String("job.id", job.ID)) {
+				analysis = span
+				return true
+			}
+		}
+		return false
+	})
+	if analysis.Parent().IsValid() {
+		t.Error("expected the analysis span to start a new trace")
+	}
+	links := analysis.Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("expected a link to the request span, got %+v", links)
+	}
+}
+
+type loggingService struct {
+	mockAnalyzerService
+}
+
+func (s *loggingService) AnalyzePage(ctx context.Context, url string) error {
+	logging.FromContext(ctx).Info("analyzing", "url", url)
+	return nil
+}
+
+func TestManager_AnalysisLogsWithRequestAndJobID(t *testing.T) {
+	var buf syncBuffer
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)).With("request_id", "req-1"))
+
+	m := NewManager(workspace.Static(&loggingService{}), 1, 1)
+	job, err := m.Submit(ctx, "http://a.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool { return strings.Contains(buf.String(), "analyzing") })
+
+	line := buf.String()
+	if !strings.Contains(line, `"request_id":"req-1"`) || !strings.Contains(line, `"job_id":"`+job.ID+`"`) {
+		t.Errorf("expected request and job IDs in %s", line)
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestManager_HealthChecks(t *testing.T) {
+	service := &mockAnalyzerService{block: make(chan struct{})}
+	defer close(service.block)
+	m := NewManager(workspace.Static(service), 1, 2)
+
+	if err := m.CheckQueue(context.Background()); err != nil {
+		t.Errorf("expected an empty queue to be healthy, got %v", err)
+	}
+	if err := m.CheckWorkers(context.Background()); err != nil {
+		t.Errorf("expected idle workers to be healthy, got %v", err)
+	}
+
+	if _, err := m.Submit(context.Background(), "http://a.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool { return m.WorkerStats(StuckAfter).Busy == 1 })
+	for _, url := range []string{"http://b.com", "http://c.com"} {
+		if _, err := m.Submit(context.Background(), url); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := m.CheckQueue(context.Background()); !errors.Is(err, ErrQueueSaturated) {
+		t.Errorf("expected ErrQueueSaturated, got %v", err)
+	}
+	if stats := m.WorkerStats(0); stats.Stuck != 1 {
+		t.Errorf("expected the busy worker to count as stuck, got %+v", stats)
+	}
+}
+
+func TestManager_List(t *testing.T) {
+	registry := workspace.Static(&mockAnalyzerService{})
+	m := NewManager(registry, 1, 10)
+	ctx := workspace.WithID(context.Background(), "team-a")
+
+	var ids []string
+	for _, url := range []string{"http://a.com", "http://b.com", "http://c.com"} {
+		job, err := m.Submit(ctx, url)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, job.ID)
+		time.Sleep(time.Millisecond)
+	}
+	if _, err := m.Submit(context.Background(), "http://d.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := m.List("team-a", 2)
+	if len(list) != 2 || list[0].ID != ids[2] || list[1].ID != ids[1] {
+		t.Errorf("expected the two newest team-a jobs, got %+v", list)
+	}
+	if got := m.List("team-a", 0); len(got) != 3 {
+		t.Errorf("expected all three team-a jobs, got %d", len(got))
+	}
+}
+
+func TestManager_ExpiresFinishedJobs(t *testing.T) {
+	m := NewManager(workspace.Static(&mockAnalyzerService{}), 2, 10)
+	m.Retention = 50 * time.Millisecond
+
+	job, err := m.Submit(context.Background(), "http://a.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	batch := m.NewBatch(context.Background())
+	batchJob, err := m.SubmitToBatch(context.Background(), batch.ID, "http://b.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool {
+		p, _ := m.BatchProgress(batch.ID)
+		j, _ := m.Job(job.ID)
+		return p.Done && j.Status == StatusCompleted
+	})
+
+	time.Sleep(2 * m.Retention)
+	recent, err := m.Submit(context.Background(), "http://c.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, id := range []string{job.ID, batchJob.ID} {
+		if _, ok := m.Job(id); ok {
+			t.Errorf("expected job %s to expire", id)
+		}
+		if _, ok := m.Subscribe(id); ok {
+			t.Errorf("expected the events of job %s to expire", id)
+		}
+	}
+	if _, ok := m.BatchProgress(batch.ID); ok {
+		t.Error("expected the batch to expire")
+	}
+	if _, ok := m.Job(recent.ID); !ok {
+		t.Fatal("expected the new job to be kept")
+	}
+	waitFor(t, func() bool {
+		j, _ := m.Job(recent.ID)
+		return j.Status == StatusCompleted
+	})
+}
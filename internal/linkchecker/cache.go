@@ -0,0 +1,93 @@
+package linkchecker
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/metrics"
+)
+
+// DefaultMaxCacheEntries is the default of CachingLinkChecker.MaxEntries.
+const DefaultMaxCacheEntries = 10000
+
+// CachingLinkChecker remembers link check results for a limited time so links
+// shared by many pages are only checked once. When it holds MaxEntries links,
+// the least recently used one is evicted.
+type CachingLinkChecker struct {
+	inner LinkChecker
+	ttl   time.Duration
+	now   func() time.Time
+
+	// MaxEntries is the number of links the cache holds at most.
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the cached links, most recently used first.
+	order *list.List
+}
+
+type cacheEntry struct {
+	url     string
+	broken  bool
+	expires time.Time
+}
+
+// NewCachingLinkChecker wraps inner with a cache whose entries expire after ttl.
+func NewCachingLinkChecker(inner LinkChecker, ttl time.Duration) *CachingLinkChecker {
+	return &CachingLinkChecker{
+		inner:      inner,
+		ttl:        ttl,
+		now:        time.Now,
+		MaxEntries: DefaultMaxCacheEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// IsBroken implements LinkChecker.
+func (c *CachingLinkChecker) IsBroken(ctx context.Context, url string) bool {
+	c.mu.Lock()
+	if elem, ok := c.entries[url]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(elem)
+			c.mu.Unlock()
+			metrics.LinkCacheHits.Inc()
+			return entry.broken
+		}
+	}
+	c.mu.Unlock()
+	metrics.LinkCacheMisses.Inc()
+
+	broken := c.inner.IsBroken(ctx, url)
+	if ctx.Err() != nil {
+		// The check was cut short, so its result says nothing about the link.
+		return broken
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := &cacheEntry{url: url, broken: broken, expires: c.now().Add(c.ttl)}
+	if elem, ok := c.entries[url]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[url] = c.order.PushFront(entry)
+	}
+	for c.MaxEntries > 0 && c.order.Len() > c.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).url)
+	}
+	return broken
+}
+
+// Len returns the number of cached links.
+func (c *CachingLinkChecker) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
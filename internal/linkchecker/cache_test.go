@@ -0,0 +1,62 @@
+package linkchecker
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type countingChecker struct {
+	calls  int
+	broken bool
+}
+
+func (c *countingChecker) IsBroken(ctx context.Context, url string) bool {
+	c.calls++
+	return c.broken
+}
+
+func TestCachingLinkChecker(t *testing.T) {
+	inner := &countingChecker{broken: true}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewCachingLinkChecker(inner, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	if !cache.IsBroken(context.Background(), "http://example.com/a") || !cache.IsBroken(context.Background(), "http://example.com/a") {
+		t.Fatal("expected cached result to be returned")
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 check, got %d", inner.calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	cache.IsBroken(context.Background(), "http://example.com/a")
+	if inner.calls != 2 {
+		t.Errorf("expected expired entry to be checked again, got %d checks", inner.calls)
+	}
+}
+
+func TestCachingLinkChecker_EvictsLeastRecentlyUsed(t *testing.T) {
+	inner := &countingChecker{}
+	cache := NewCachingLinkChecker(inner, time.Hour)
+	cache.MaxEntries = 2
+	ctx := context.Background()
+
+	cache.IsBroken(ctx, "http://example.com/a")
+	cache.IsBroken(ctx, "http://example.com/b")
+	cache.IsBroken(ctx, "http://example.com/a")
+	cache.IsBroken(ctx, "http://example.com/c")
+	if got := cache.Len(); got != 2 {
+		t.Fatalf("expected cache to hold 2 links, got %d", got)
+	}
+
+	calls := inner.calls
+	cache.IsBroken(ctx, "http://example.com/a")
+	if inner.calls != calls {
+		t.Error("expected recently used link to stay cached")
+	}
+	cache.IsBroken(ctx, "http://example.com/b")
+	if inner.calls != calls+1 {
+		t.Error("expected least recently used link to be evicted")
+	}
+}
@@ -1,22 +1,35 @@
 package linkchecker
 
 import (
-	"log/slog"
+	"context"
 	"net/http"
 	"time"
+
+	"web-analyzer/internal/logging"
 )
 
 type LinkChecker interface {
-	IsBroken(url string) bool
+	IsBroken(ctx context.Context, url string) bool
 }
 
 type DefaultLinkChecker struct{}
 
-func (d DefaultLinkChecker) IsBroken(url string) bool {
+func (d DefaultLinkChecker) IsBroken(ctx context.Context, url string) bool {
+	logger := logging.FromContext(ctx)
 	client := http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Head(url)
-	if err != nil || resp.StatusCode >= 400 {
-		slog.Debug("Broken or failed link", "url", url, "error", err, "status", resp.StatusCode)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		logger.Debug("Invalid link", "url", url, "error", err)
+		return true
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.Debug("Failed link", "url", url, "error", err)
+		return true
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		logger.Debug("Broken link", "url", url, "status", resp.StatusCode)
 		return true
 	}
 	return false
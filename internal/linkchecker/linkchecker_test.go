@@ -1,6 +1,7 @@
 package linkchecker
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -60,7 +61,7 @@ func TestDefaultLinkChecker_IsBroken(t *testing.T) {
 
 			// Create a DefaultLinkChecker and test IsBroken
 			checker := DefaultLinkChecker{}
-			isBroken := checker.IsBroken(url)
+			isBroken := checker.IsBroken(context.Background(), url)
 			if isBroken != tt.expectedBroken {
 				t.Errorf("IsBroken(%q) = %v; want %v", url, isBroken, tt.expectedBroken)
 			}
@@ -0,0 +1,207 @@
+// Package listing filters, sorts and paginates the submitted URLs of a
+// workspace. Pages are addressed with opaque cursors, so they stay stable while
+// new URLs are submitted.
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"web-analyzer/models"
+)
+
+const (
+	// DefaultLimit is the page size used when none is given.
+	DefaultLimit = 50
+	// MaxLimit is the largest page size accepted.
+	MaxLimit = 200
+)
+
+// ErrInvalidCursor is returned for cursors that were not produced by a
+// previous page with the same sort.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Sort is the field URLs are ordered by.
+type Sort string
+
+const (
+	// SortSubmitted orders URLs by the time they were last submitted.
+	SortSubmitted Sort = "submitted"
+	// SortAnalyzed orders URLs by the time they were last analysed. URLs that
+	// were never analysed come last in descending order.
+	SortAnalyzed Sort = "analyzed"
+)
+
+// ParseSort returns the Sort named by s. It defaults to SortSubmitted when s is
+// empty.
+func ParseSort(s string) (Sort, error) {
+	switch Sort(strings.ToLower(s)) {
+	case "", SortSubmitted:
+		return SortSubmitted, nil
+	case SortAnalyzed:
+		return SortAnalyzed, nil
+	}
+	return "", fmt.Errorf("unsupported sort %q", s)
+}
+
+// Query selects a page of URLs. Zero-valued filters match everything.
+type Query struct {
+	// Host matches the hostname of the URL, case-insensitively.
+	Host string
+	// Status matches the status of the latest analysis, case-insensitively.
+	Status string
+	// Prefix matches the start of the URL.
+	Prefix string
+	// Search matches a case-insensitive substring of the URL or page title.
+	Search string
+
+	Sort Sort
+	// Ascending reverses the default newest-first order.
+	Ascending bool
+	// Limit is the page size, DefaultLimit when zero and at most MaxLimit.
+	Limit int
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+}
+
+// Page is a page of URLs.
+type Page struct {
+	URLs []models.URLEntry `json:"urls"`
+	// Total is the number of URLs matching the filters, across all pages.
+	Total int `json:"total"`
+	// NextCursor fetches the following page. It is empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// cursor identifies the last entry of a page by its sort key.
+type cursor struct {
+	Sort Sort   `json:"s"`
+	Time int64  `json:"t"`
+	URL  string `json:"u"`
+}
+
+// Apply returns the page of entries selected by q.
+func (q Query) Apply(entries []models.URLEntry) (Page, error) {
+	if q.Sort == "" {
+		q.Sort = SortSubmitted
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	matched := make([]models.URLEntry, 0, len(entries))
+	for _, entry := range entries {
+		if q.matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return q.before(matched[i], matched[j]) })
+
+	start := 0
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor)
+		if err != nil || after.Sort != q.Sort {
+			return Page{}, ErrInvalidCursor
+		}
+		// Resume at the first entry ordered after the one the cursor points
+		// at, which still works when that entry has since been updated.
+		start = sort.Search(len(matched), func(i int) bool {
+			return q.keyBefore(after.Time, after.URL, q.key(matched[i]), matched[i].URL)
+		})
+	}
+
+	end := min(start+limit, len(matched))
+	page := Page{URLs: matched[start:end], Total: len(matched)}
+	if end < len(matched) {
+		last := matched[end-1]
+		page.NextCursor = encodeCursor(cursor{Sort: q.Sort, Time: q.key(last), URL: last.URL})
+	}
+	return page, nil
+}
+
+func (q Query) matches(entry models.URLEntry) bool {
+	if q.Host != "" && !strings.EqualFold(hostOf(entry.URL), q.Host) {
+		return false
+	}
+	if q.Status != "" && !strings.EqualFold(entry.LastStatus, q.Status) {
+		return false
+	}
+	if q.Prefix != "" && !strings.HasPrefix(entry.URL, q.Prefix) {
+		return false
+	}
+	if q.Search != "" {
+		search := strings.ToLower(q.Search)
+		if !strings.Contains(strings.ToLower(entry.URL), search) &&
+			!strings.Contains(strings.ToLower(entry.LastTitle), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// key returns the sort key of entry in nanoseconds. Entries without a time sort
+// as the zero value.
+func (q Query) key(entry models.URLEntry) int64 {
+	var t time.Time
+	switch q.Sort {
+	case SortAnalyzed:
+		if entry.LastAnalyzedAt != nil {
+			t = *entry.LastAnalyzedAt
+		}
+	default:
+		t = entry.LastSubmittedAt
+	}
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func (q Query) before(a, b models.URLEntry) bool {
+	return q.keyBefore(q.key(a), a.URL, q.key(b), b.URL)
+}
+
+// keyBefore reports whether the sort key (ta, ua) orders before (tb, ub). Ties
+// on time are broken by URL so the order is total.
+func (q Query) keyBefore(ta int64, ua string, tb int64, ub string) bool {
+	if ta != tb {
+		if q.Ascending {
+			return ta < tb
+		}
+		return ta > tb
+	}
+	return ua < ub
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, err
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return cursor{}, err
+	}
+	return c, nil
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
@@ -0,0 +1,124 @@
+package listing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"web-analyzer/models"
+)
+
+func entries() []models.URLEntry {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	analyzed := base.Add(time.Hour)
+	return []models.URLEntry{
+		{URL: "https://a.com/", LastSubmittedAt: base, LastStatus: "Completed", LastTitle: "Home"},
+		{URL: "https://a.com/blog", LastSubmittedAt: base.Add(2 * time.Minute), LastStatus: "Failed"},
+		{URL: "https://b.org/", LastSubmittedAt: base.Add(time.Minute), LastStatus: "Completed", LastAnalyzedAt: &analyzed},
+	}
+}
+
+func urls(page Page) []string {
+	var list []string
+	for _, entry := range page.URLs {
+		list = append(list, entry.URL)
+	}
+	return list
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuery_Sort(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"newest submission first", Query{}, []string{"https://a.com/blog", "https://b.org/", "https://a.com/"}},
+		{"oldest submission first", Query{Ascending: true}, []string{"https://a.com/", "https://b.org/", "https://a.com/blog"}},
+		{"last analysed first", Query{Sort: SortAnalyzed}, []string{"https://b.org/", "https://a.com/", "https://a.com/blog"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.query.Apply(entries())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := urls(page); !equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestQuery_Filters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{"host", Query{Host: "A.com"}, []string{"https://a.com/blog", "https://a.com/"}},
+		{"status", Query{Status: "failed"}, []string{"https://a.com/blog"}},
+		{"prefix", Query{Prefix: "https://b."}, []string{"https://b.org/"}},
+		{"search matches title", Query{Search: "home"}, []string{"https://a.com/"}},
+		{"search matches URL", Query{Search: "BLOG"}, []string{"https://a.com/blog"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tt.query.Apply(entries())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := urls(page); !equal(got, tt.want) || page.Total != len(tt.want) {
+				t.Errorf("expected %v, got %v (total %d)", tt.want, got, page.Total)
+			}
+		})
+	}
+}
+
+func TestQuery_Pagination(t *testing.T) {
+	query := Query{Limit: 2}
+	first, err := query.Apply(entries())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.URLs) != 2 || first.Total != 3 || first.NextCursor == "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+
+	query.Cursor = first.NextCursor
+	second, err := query.Apply(entries())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := urls(second); !equal(got, []string{"https://a.com/"}) || second.NextCursor != "" {
+		t.Errorf("unexpected second page: %v, cursor %q", got, second.NextCursor)
+	}
+
+	query.Sort = SortAnalyzed
+	if _, err := query.Apply(entries()); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected a cursor of another sort to be rejected, got %v", err)
+	}
+	if _, err := (Query{Cursor: "not a cursor"}).Apply(entries()); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	if s, err := ParseSort(""); err != nil || s != SortSubmitted {
+		t.Errorf("expected default sort, got %q, %v", s, err)
+	}
+	if _, err := ParseSort("score"); err == nil {
+		t.Error("expected an unknown sort to be rejected")
+	}
+}
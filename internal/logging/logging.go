@@ -0,0 +1,146 @@
+// Package logging provides the service's structured logger. A logger carrying
+// correlation attributes such as the request ID and job ID travels in the
+// context, so log lines from handlers and background work can be tied together.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the correlation ID of a request. It is taken from the
+// incoming request when present and echoed on the response.
+const RequestIDHeader = "X-Request-ID"
+
+// MaxRequestIDLength bounds client supplied request IDs.
+const MaxRequestIDLength = 128
+
+// level is the minimum level of the loggers created by New. It can be changed
+// while the server runs.
+var level = new(slog.LevelVar)
+
+type loggerKey struct{}
+
+// New returns a JSON logger writing to w whose level follows SetLevel.
+func New(w io.Writer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// Level returns the current minimum log level.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel changes the minimum log level of the loggers created by New.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// ParseLevel parses "debug", "info", "warn" or "error", case-insensitively.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", s)
+	}
+	return l, nil
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger has the given attributes added.
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// RequestID assigns every request an ID, reusing the X-Request-ID header when
+// the client sent one, and stores a logger tagged with it in the request
+// context. The trace ID is added as well when the request is traced.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > MaxRequestIDLength {
+			id = NewRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+
+		args := []any{"request_id", id}
+		ctx := c.Request.Context()
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			args = append(args, "trace_id", sc.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(With(ctx, args...))
+		c.Next()
+	}
+}
+
+// AccessLog writes one log line per request once it has been served. Server
+// errors are logged at error level and client errors at warn level.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		l := slog.LevelInfo
+		switch {
+		case status >= 500:
+			l = slog.LevelError
+		case status >= 400:
+			l = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		ctx := c.Request.Context()
+		FromContext(ctx).LogAttrs(ctx, l, "HTTP request", attrs...)
+	}
+}
+
+// Recovery turns panics in handlers into 500 responses and logs them, with the
+// stack trace, through the request logger.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		FromContext(c.Request.Context()).Error("Panic recovered", "error", err, "stack", string(debug.Stack()))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithLogger(c.Request.Context(), logger))
+	}, RequestID(), AccessLog(), Recovery())
+	r.GET("/ping", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("handling")
+		c.String(http.StatusOK, "pong")
+	})
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestRequestID_PropagatesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(&buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	if got := resp.Header().Get(RequestIDHeader); got != "abc-123" {
+		t.Errorf("expected the request ID to be echoed, got %q", got)
+	}
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected a handler line and an access log line, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if line["request_id"] != "abc-123" {
+			t.Errorf("expected request_id on every line, got %v", line)
+		}
+	}
+	access := lines[1]
+	if access["msg"] != "HTTP request" || access["status"] != float64(200) || access["route"] != "/ping" {
+		t.Errorf("unexpected access log: %v", access)
+	}
+}
+
+func TestRequestID_GeneratesID(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(&buf)
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	if id := resp.Header().Get(RequestIDHeader); len(id) != 32 {
+		t.Errorf("expected a generated request ID, got %q", id)
+	}
+}
+
+func TestRecovery_LogsPanic(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(&buf)
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if resp.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", resp.Code)
+	}
+	lines := decodeLines(t, &buf)
+	if lines[0]["msg"] != "Panic recovered" || lines[len(lines)-1]["level"] != "ERROR" {
+		t.Errorf("unexpected log lines: %v", lines)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel(Level())
+
+	var buf bytes.Buffer
+	logger := New(&buf)
+	SetLevel(slog.LevelWarn)
+	logger.Info("hidden")
+	SetLevel(slog.LevelDebug)
+	logger.Debug("shown")
+
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("level changes were not applied: %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, err := ParseLevel("DEBUG"); err != nil || l != slog.LevelDebug {
+		t.Errorf("ParseLevel(DEBUG) = %v, %v", l, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+}
+
+func TestFromContext_DefaultsToDefaultLogger(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("expected the default logger")
+	}
+}
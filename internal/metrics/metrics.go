@@ -0,0 +1,154 @@
+// Package metrics defines the Prometheus collectors exposed on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const namespace = "web_analyzer"
+
+// Registry holds every collector of the service, including the Go runtime and
+// process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	AnalysesStarted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyses_started_total",
+		Help:      "Number of analyses started.",
+	})
+	AnalysesCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyses_completed_total",
+		Help:      "Number of analyses completed successfully.",
+	})
+	AnalysesFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "analyses_failed_total",
+		Help:      "Number of failed analyses by reason.",
+	}, []string{"reason"})
+
+	FetchDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "fetch_duration_seconds",
+		Help:      "Time to fetch a page, including redirects.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	LinkCheckDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "link_check_duration_seconds",
+		Help:      "Time to check a single link.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	LinkChecksInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "link_checks_in_flight",
+		Help:      "Number of link checks currently running.",
+	})
+
+	LinkCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "link_cache_hits_total",
+		Help:      "Number of link checks answered from the cache.",
+	})
+	LinkCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "link_cache_misses_total",
+		Help:      "Number of link checks that missed the cache.",
+	})
+
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		AnalysesStarted,
+		AnalysesCompleted,
+		AnalysesFailed,
+		FetchDuration,
+		LinkCheckDuration,
+		LinkChecksInFlight,
+		LinkCacheHits,
+		LinkCacheMisses,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "link_cache_hit_ratio",
+			Help:      "Share of link checks answered from the cache since start.",
+		}, cacheHitRatio),
+		HTTPRequests,
+		HTTPRequestDuration,
+	)
+}
+
+// RegisterQueueDepth exposes the number of queued analyses reported by depth.
+func RegisterQueueDepth(depth func() int) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "queue_depth",
+		Help:      "Number of analyses waiting for a worker.",
+	}, func() float64 { return float64(depth()) }))
+}
+
+// Handler serves the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records request counts and durations. Requests are labelled with
+// the route pattern rather than the raw path to keep cardinality bounded.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		HTTPRequests.WithLabelValues(route, c.Request.Method, status).Inc()
+		HTTPRequestDuration.WithLabelValues(route, c.Request.Method, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveSince records the time elapsed since start on h.
+func ObserveSince(h prometheus.Observer, start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+func cacheHitRatio() float64 {
+	hits := counterValue(LinkCacheHits)
+	total := hits + counterValue(LinkCacheMisses)
+	if total == 0 {
+		return 0
+	}
+	return hits / total
+}
+
+func counterValue(c prometheus.Counter) float64 {
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
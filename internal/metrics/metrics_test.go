@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddlewareAndHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+	r.GET("/metrics", gin.WrapH(Handler()))
+
+	req, _ := http.NewRequest(http.MethodGet, "/items/42", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	LinkCacheHits.Inc()
+	LinkCacheMisses.Inc()
+
+	req, _ = http.NewRequest(http.MethodGet, "/metrics", nil)
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	body := resp.Body.String()
+	for _, want := range []string{
+		`web_analyzer_http_requests_total{method="GET",route="/items/:id",status="418"} 1`,
+		`web_analyzer_http_request_duration_seconds_count{method="GET",route="/items/:id",status="418"} 1`,
+		"web_analyzer_link_cache_hit_ratio 0.5",
+		"web_analyzer_analyses_started_total",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected metrics output to contain %q", want)
+		}
+	}
+}
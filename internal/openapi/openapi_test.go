@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type color string
+
+func (color) Values() []string { return []string{"red", "green"} }
+
+type node struct {
+	Name     string    `json:"name"`
+	Color    color     `json:"color"`
+	Children []node    `json:"children,omitempty"`
+	Parent   *node     `json:"parent,omitempty"`
+	Created  time.Time `json:"created_at"`
+	Tags     map[string]int
+	Skipped  string `json:"-"`
+	internal string
+}
+
+func TestDocument_Schema(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+	ref := doc.Schema(node{})
+	if ref.Ref != "#/components/schemas/node" {
+		t.Fatalf("expected a reference to node, got %+v", ref)
+	}
+
+	s := doc.Components.Schemas["node"]
+	if s == nil || s.Type != "object" {
+		t.Fatalf("expected node to be registered as an object, got %+v", s)
+	}
+	if want := []string{"name", "color", "created_at", "Tags"}; !reflect.DeepEqual(s.Required, want) {
+		t.Errorf("required = %v; want %v", s.Required, want)
+	}
+	if got := s.Properties["color"]; got.Type != "string" || !reflect.DeepEqual(got.Enum, []string{"red", "green"}) {
+		t.Errorf("expected color to be an enum, got %+v", got)
+	}
+	if got := s.Properties["children"]; got.Type != "array" || got.Items.Ref != ref.Ref {
+		t.Errorf("expected children to reference node, got %+v", got)
+	}
+	if got := s.Properties["parent"]; !got.Nullable || len(got.AllOf) != 1 || got.AllOf[0].Ref != ref.Ref {
+		t.Errorf("expected parent to be a nullable reference, got %+v", got)
+	}
+	if got := s.Properties["created_at"]; got.Format != "date-time" {
+		t.Errorf("expected created_at to be a date-time, got %+v", got)
+	}
+	if got := s.Properties["Tags"]; got.Type != "object" || got.AdditionalProperties.Type != "integer" {
+		t.Errorf("expected Tags to be a map of integers, got %+v", got)
+	}
+	for _, name := range []string{"Skipped", "-", "internal"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("expected %s to be skipped", name)
+		}
+	}
+}
+
+func TestDocument_Add(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"})
+	doc.Add("GET", "/jobs/:id", Operation{OperationID: "getJob"})
+	doc.Add("DELETE", "/jobs/:id", Operation{OperationID: "deleteJob"})
+
+	item, ok := doc.Paths["/jobs/{id}"]
+	if !ok {
+		t.Fatalf("expected path parameters to be converted, got %v", doc.Paths)
+	}
+	if item["get"].OperationID != "getJob" || item["delete"].OperationID != "deleteJob" {
+		t.Errorf("unexpected operations: %+v", item)
+	}
+}
@@ -0,0 +1,392 @@
+// Package performance estimates the weight of a page and flags common loading
+// problems from its markup and, optionally, its fetched subresources. It is a
+// static approximation of a browser performance audit.
+package performance
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/htmlutil"
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+// Defaults of Options.
+const (
+	DefaultMaxResourceBytes = 5 << 20
+	DefaultMaxTotalBytes    = 25 << 20
+	DefaultMaxRequests      = 100
+	DefaultConcurrency      = 4
+	DefaultTimeout          = 10 * time.Second
+)
+
+// minCompressibleBytes is the size below which uncompressed text resources
+// are not reported.
+const minCompressibleBytes = 1 << 10
+
+// DocumentType is the key of the page's own document in
+// models.Performance.BytesByType.
+const DocumentType = "Document"
+
+// Options configures an audit.
+type Options struct {
+	// FetchResources fetches the subresources of the page to measure their
+	// size, compression and caching. Only the markup is audited otherwise.
+	FetchResources bool
+	// MaxResourceBytes caps the bytes read from a single resource.
+	MaxResourceBytes int64
+	// MaxTotalBytes caps the bytes read from all resources of a page.
+	MaxTotalBytes int64
+	// MaxRequests caps the number of resources fetched for a page.
+	MaxRequests int
+	// Concurrency is the number of resources fetched at once.
+	Concurrency int
+	// Timeout bounds the fetch of a single resource, so a stalled server
+	// cannot hold up the analysis.
+	Timeout time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxResourceBytes <= 0 {
+		o.MaxResourceBytes = DefaultMaxResourceBytes
+	}
+	if o.MaxTotalBytes <= 0 {
+		o.MaxTotalBytes = DefaultMaxTotalBytes
+	}
+	if o.MaxRequests <= 0 {
+		o.MaxRequests = DefaultMaxRequests
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	return o
+}
+
+// Page is an analysed page.
+type Page struct {
+	URL string
+	Doc *html.Node
+	// Header is the header of the document's response, nil for uploads.
+	Header http.Header
+	// Bytes is the size of the document as read.
+	Bytes int64
+	// Compressed is set when the transport decompressed the document, so that
+	// Bytes is its uncompressed size and Header lacks its Content-Encoding.
+	Compressed bool
+	Resources  []models.Resource
+}
+
+// Audit measures the weight of a page. Subresources are fetched with client
+// when opts.FetchResources is set.
+func Audit(ctx context.Context, client *http.Client, opts Options, page Page) *models.Performance {
+	opts = opts.withDefaults()
+	base, err := neturl.Parse(page.URL)
+	if err != nil {
+		base = &neturl.URL{}
+	}
+
+	perf := &models.Performance{
+		DocumentBytes: page.Bytes,
+		TotalBytes:    page.Bytes,
+		BytesByType:   map[string]int64{DocumentType: page.Bytes},
+		Requests:      1,
+	}
+	auditMarkup(perf, page.Doc, base)
+
+	var fetchable []models.Resource
+	seen := make(map[string]bool)
+	for _, r := range page.Resources {
+		u, err := neturl.Parse(r.URL)
+		if err != nil || u.Host == "" || seen[r.URL] {
+			continue
+		}
+		seen[r.URL] = true
+		fetchable = append(fetchable, r)
+	}
+	perf.Requests += len(fetchable)
+
+	if page.Header != nil && !page.Compressed && !isCompressed(page.Header) && isCompressible(page.Header) && page.Bytes >= minCompressibleBytes {
+		perf.Uncompressed = append(perf.Uncompressed, page.URL)
+	}
+	if opts.FetchResources && client != nil {
+		fetchAll(ctx, client, opts, perf, fetchable)
+		// Fetches complete in any order.
+		sort.Strings(perf.Uncompressed)
+		sort.Strings(perf.Uncached)
+	}
+	return perf
+}
+
+// Issues summarises the problems of an audit in human readable form.
+func Issues(p *models.Performance) []string {
+	if p == nil {
+		return nil
+	}
+	var issues []string
+	count := func(urls []string, format string) {
+		if len(urls) > 0 {
+			issues = append(issues, fmt.Sprintf(format, len(urls)))
+		}
+	}
+	count(p.RenderBlocking, "%d render-blocking resources in <head>")
+	count(p.ImagesWithoutDimensions, "%d images without width and height")
+	count(p.ImagesWithoutLazyLoading, "%d images without lazy loading")
+	count(p.Uncompressed, "%d uncompressed text resources")
+	count(p.Uncached, "%d resources not cacheable")
+	return issues
+}
+
+// auditMarkup records the render-blocking resources in <head> and the images
+// missing dimensions or lazy loading.
+func auditMarkup(perf *models.Performance, doc *html.Node, base *neturl.URL) {
+	images := 0
+	var traverse func(n *html.Node, inHead bool)
+	traverse = func(n *html.Node, inHead bool) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "head":
+				inHead = true
+			case "script":
+				if src, ok := htmlutil.Attr(n, "src"); ok && inHead && blocksScript(n) {
+					perf.RenderBlocking = append(perf.RenderBlocking, resolve(base, src))
+				}
+			case "link":
+				if href, ok := htmlutil.Attr(n, "href"); ok && inHead && blocksStylesheet(n) {
+					perf.RenderBlocking = append(perf.RenderBlocking, resolve(base, href))
+				}
+			case "img":
+				src, _ := htmlutil.Attr(n, "src")
+				src = resolve(base, src)
+				_, hasWidth := htmlutil.Attr(n, "width")
+				_, hasHeight := htmlutil.Attr(n, "height")
+				if !hasWidth || !hasHeight {
+					perf.ImagesWithoutDimensions = append(perf.ImagesWithoutDimensions, src)
+				}
+				// The first image is likely above the fold, where lazy loading
+				// delays rendering.
+				if loading, _ := htmlutil.Attr(n, "loading"); images > 0 && !strings.EqualFold(loading, "lazy") {
+					perf.ImagesWithoutLazyLoading = append(perf.ImagesWithoutLazyLoading, src)
+				}
+				images++
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c, inHead)
+		}
+	}
+	if doc != nil {
+		traverse(doc, false)
+	}
+}
+
+// blocksScript reports whether an external script blocks parsing.
+func blocksScript(n *html.Node) bool {
+	if _, ok := htmlutil.Attr(n, "async"); ok {
+		return false
+	}
+	if _, ok := htmlutil.Attr(n, "defer"); ok {
+		return false
+	}
+	typ, _ := htmlutil.Attr(n, "type")
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case "", "text/javascript", "application/javascript":
+		return true
+	}
+	// Modules are deferred and other types are not executed.
+	return false
+}
+
+// blocksStylesheet reports whether a <link> is a stylesheet that blocks
+// rendering on screens.
+func blocksStylesheet(n *html.Node) bool {
+	rel, _ := htmlutil.Attr(n, "rel")
+	if !htmlutil.HasToken(rel, "stylesheet") || htmlutil.HasToken(rel, "alternate") {
+		return false
+	}
+	if _, ok := htmlutil.Attr(n, "disabled"); ok {
+		return false
+	}
+	media, _ := htmlutil.Attr(n, "media")
+	media = strings.ToLower(strings.TrimSpace(media))
+	return media == "" || media == "all" || strings.Contains(media, "screen")
+}
+
+// fetchAll fetches resources within the caps of opts and records their sizes,
+// compression and caching.
+func fetchAll(ctx context.Context, client *http.Client, opts Options, perf *models.Performance, resources []models.Resource) {
+	if len(resources) > opts.MaxRequests {
+		resources = resources[:opts.MaxRequests]
+		perf.Truncated = true
+	}
+	perf.Fetched = true
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	total := &budget{remaining: opts.MaxTotalBytes}
+	sem := make(chan struct{}, opts.Concurrency)
+	defer wg.Wait()
+	for _, r := range resources {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		if total.exhausted() {
+			<-sem
+			mu.Lock()
+			perf.Truncated = true
+			mu.Unlock()
+			return
+		}
+		wg.Add(1)
+		go func(r models.Resource) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, ok := fetch(ctx, client, r.URL, opts.MaxResourceBytes, opts.Timeout, total)
+			if !ok {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			perf.TotalBytes += res.bytes
+			perf.BytesByType[r.Type] += res.bytes
+			if res.truncated {
+				perf.Truncated = true
+			}
+			if !isCompressed(res.header) && isCompressible(res.header) && res.bytes >= minCompressibleBytes {
+				perf.Uncompressed = append(perf.Uncompressed, r.URL)
+			}
+			if !isCached(res.header) {
+				perf.Uncached = append(perf.Uncached, r.URL)
+			}
+		}(r)
+	}
+}
+
+// budget is the number of bytes left to read from the resources of a page.
+type budget struct {
+	mu        sync.Mutex
+	remaining int64
+}
+
+// take grants up to n bytes of the budget.
+func (b *budget) take(n int64) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n = min(n, b.remaining)
+	b.remaining -= n
+	return n
+}
+
+func (b *budget) exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining <= 0
+}
+
+type fetched struct {
+	header    http.Header
+	bytes     int64
+	truncated bool
+}
+
+// fetch downloads at most limit bytes of url, drawn from total, within
+// timeout. Compression is requested explicitly so the transport neither
+// decompresses the body nor drops its Content-Encoding, and the bytes counted
+// are those transferred.
+func fetch(ctx context.Context, client *http.Client, url string, limit int64, timeout time.Duration, total *budget) (fetched, bool) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fetched{}, false
+	}
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fetched{}, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fetched{}, false
+	}
+
+	res := fetched{header: resp.Header}
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			granted := total.take(min(int64(n), limit-res.bytes))
+			res.bytes += granted
+			if granted < int64(n) {
+				res.truncated = true
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return res, true
+}
+
+func isCompressed(h http.Header) bool {
+	encoding := strings.ToLower(strings.TrimSpace(h.Get("Content-Encoding")))
+	return encoding != "" && encoding != "identity"
+}
+
+// isCompressible reports whether a response is text that compresses well.
+func isCompressible(h http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/javascript", "application/x-javascript", "application/json",
+		"application/xml", "image/svg+xml", "application/manifest+json":
+		return true
+	}
+	return false
+}
+
+// isCached reports whether a response may be reused from a cache, either for a
+// lifetime set by Cache-Control or, once revalidated, through its ETag.
+func isCached(h http.Header) bool {
+	maxAge := -1
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			return false
+		case "max-age", "s-maxage":
+			if n, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && n > maxAge {
+				maxAge = n
+			}
+		}
+	}
+	return maxAge > 0 || (maxAge < 0 && h.Get("ETag") != "")
+}
+
+func resolve(base *neturl.URL, ref string) string {
+	u, err := neturl.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,214 @@
+package performance
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+func parse(t *testing.T, src string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return doc
+}
+
+func TestAudit_Markup(t *testing.T) {
+	doc := parse(t, `<html><head>
+		<script src="/blocking.js"></script>
+		<script src="/async.js" async></script>
+		<script src="/deferred.js" defer></script>
+		<script type="module" src="/module.js"></script>
+		<link rel="stylesheet" href="/style.css">
+		<link rel="stylesheet" href="/print.css" media="print">
+		<link rel="alternate stylesheet" href="/alt.css">
+		<link rel="icon" href="/favicon.ico">
+	</head><body>
+		<script src="/body.js"></script>
+		<img src="/hero.png">
+		<img src="/sized.png" width="10" height="10">
+		<img src="/lazy.png" width="10" height="10" loading="lazy">
+	</body></html>`)
+
+	perf := Audit(context.Background(), nil, Options{}, Page{URL: "https://example.com/", Doc: doc, Bytes: 100})
+
+	if want := []string{"https://example.com/blocking.js", "https://example.com/style.css"}; !reflect.DeepEqual(perf.RenderBlocking, want) {
+		t.Errorf("RenderBlocking = %v; want %v", perf.RenderBlocking, want)
+	}
+	if want := []string{"https://example.com/hero.png"}; !reflect.DeepEqual(perf.ImagesWithoutDimensions, want) {
+		t.Errorf("ImagesWithoutDimensions = %v; want %v", perf.ImagesWithoutDimensions, want)
+	}
+	// The first image is exempt from lazy loading.
+	if want := []string{"https://example.com/sized.png"}; !reflect.DeepEqual(perf.ImagesWithoutLazyLoading, want) {
+		t.Errorf("ImagesWithoutLazyLoading = %v; want %v", perf.ImagesWithoutLazyLoading, want)
+	}
+	if perf.Fetched || perf.TotalBytes != 100 || perf.BytesByType[DocumentType] != 100 {
+		t.Errorf("unexpected sizes: %+v", perf)
+	}
+}
+
+func TestAudit_FetchesResources(t *testing.T) {
+	css := strings.Repeat("body { color: red; }\n", 100)
+	var gzipped bytes.Buffer
+	zw := gzip.NewWriter(&gzipped)
+	zw.Write([]byte(css))
+	zw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/plain.css":
+			w.Header().Set("Content-Type", "text/css")
+			w.Header().Set("Cache-Control", "no-store")
+			w.Write([]byte(css))
+		case "/gzip.css":
+			w.Header().Set("Content-Type", "text/css")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Set("Cache-Control", "public, max-age=86400")
+			w.Write(gzipped.Bytes())
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Header().Set("ETag", `"v1"`)
+			w.Write(make([]byte, 3000))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	page := Page{
+		URL:    srv.URL + "/",
+		Header: http.Header{"Content-Type": {"text/html"}, "Content-Encoding": {"gzip"}},
+		Bytes:  500,
+		Resources: []models.Resource{
+			{URL: srv.URL + "/plain.css", Type: models.ResourceStylesheet},
+			{URL: srv.URL + "/gzip.css", Type: models.ResourceStylesheet},
+			{URL: srv.URL + "/logo.png", Type: models.ResourceImage},
+			{URL: srv.URL + "/logo.png", Type: models.ResourceCSS},
+			{URL: srv.URL + "/missing.js", Type: models.ResourceScript},
+		},
+	}
+	perf := Audit(context.Background(), srv.Client(), Options{FetchResources: true}, page)
+
+	if !perf.Fetched || perf.Truncated {
+		t.Errorf("Fetched = %v, Truncated = %v", perf.Fetched, perf.Truncated)
+	}
+	if perf.Requests != 5 {
+		t.Errorf("Requests = %d; want 5", perf.Requests)
+	}
+	wantStyles := int64(len(css) + gzipped.Len())
+	if got := perf.BytesByType[models.ResourceStylesheet]; got != wantStyles {
+		t.Errorf("stylesheet bytes = %d; want %d", got, wantStyles)
+	}
+	if got := perf.BytesByType[models.ResourceImage]; got != 3000 {
+		t.Errorf("image bytes = %d; want 3000", got)
+	}
+	if want := 500 + wantStyles + 3000; perf.TotalBytes != want {
+		t.Errorf("TotalBytes = %d; want %d", perf.TotalBytes, want)
+	}
+	if want := []string{srv.URL + "/plain.css"}; !reflect.DeepEqual(perf.Uncompressed, want) {
+		t.Errorf("Uncompressed = %v; want %v", perf.Uncompressed, want)
+	}
+	if want := []string{srv.URL + "/plain.css"}; !reflect.DeepEqual(perf.Uncached, want) {
+		t.Errorf("Uncached = %v; want %v", perf.Uncached, want)
+	}
+}
+
+func TestAudit_Caps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write(make([]byte, 1000))
+	}))
+	defer srv.Close()
+
+	var resources []models.Resource
+	for _, name := range []string{"a", "b", "c"} {
+		resources = append(resources, models.Resource{URL: srv.URL + "/" + name, Type: models.ResourceImage})
+	}
+	tests := []struct {
+		name string
+		opts Options
+		want int64
+	}{
+		{"resource cap", Options{MaxResourceBytes: 400, Concurrency: 1}, 1200},
+		{"total cap", Options{MaxTotalBytes: 1500, Concurrency: 1}, 1500},
+		{"request cap", Options{MaxRequests: 2}, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.FetchResources = true
+			perf := Audit(context.Background(), srv.Client(), tt.opts, Page{URL: srv.URL, Resources: resources})
+			if !perf.Truncated {
+				t.Error("expected a truncated audit")
+			}
+			if got := perf.BytesByType[models.ResourceImage]; got != tt.want {
+				t.Errorf("image bytes = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAudit_TimesOutStalledResources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/stalled.js" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write(make([]byte, 100))
+	}))
+	defer srv.Close()
+
+	page := Page{URL: srv.URL, Resources: []models.Resource{
+		{URL: srv.URL + "/stalled.js", Type: models.ResourceScript},
+		{URL: srv.URL + "/logo.png", Type: models.ResourceImage},
+	}}
+	start := time.Now()
+	perf := Audit(context.Background(), srv.Client(), Options{FetchResources: true, Timeout: 50 * time.Millisecond}, page)
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("audit took %v; want the stalled resource to time out", elapsed)
+	}
+	if perf.BytesByType[models.ResourceScript] != 0 || perf.BytesByType[models.ResourceImage] != 100 {
+		t.Errorf("unexpected sizes: %v", perf.BytesByType)
+	}
+}
+
+func TestIsCached(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   bool
+	}{
+		{http.Header{"Cache-Control": {"public, max-age=3600"}}, true},
+		{http.Header{"Cache-Control": {"max-age=0"}, "Etag": {`"x"`}}, false},
+		{http.Header{"Cache-Control": {"no-store"}, "Etag": {`"x"`}}, false},
+		{http.Header{"Etag": {`"x"`}}, true},
+		{http.Header{}, false},
+	}
+	for _, tt := range tests {
+		if got := isCached(tt.header); got != tt.want {
+			t.Errorf("isCached(%v) = %v; want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestIssues(t *testing.T) {
+	if Issues(nil) != nil {
+		t.Error("expected no issues without an audit")
+	}
+	got := Issues(&models.Performance{RenderBlocking: []string{"a", "b"}, Uncached: []string{"c"}})
+	want := []string{"2 render-blocking resources in <head>", "1 resources not cacheable"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Issues = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+// Package problem writes RFC 7807 problem details. Routes opt in with
+// Middleware; elsewhere errors keep the {"error": "..."} body of the original
+// API, so shared middleware can report errors in the style of either.
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+const contextKey = "problem.enabled"
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	// Type identifies the problem type. It is "about:blank" when the problem
+	// has no meaning beyond its HTTP status code.
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Instance is the path of the request that caused the problem.
+	Instance string `json:"instance,omitempty"`
+	// Errors lists the rejected items of a request, e.g. invalid batch entries.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// New returns a problem of status with a human readable detail.
+func New(status int, detail string) Problem {
+	return Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// Middleware makes Abort respond with problem details on the routes it is
+// registered on.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(contextKey, true)
+		c.Next()
+	}
+}
+
+// Enabled reports whether the route of the request responds with problem
+// details.
+func Enabled(c *gin.Context) bool {
+	return c.GetBool(contextKey)
+}
+
+// Write writes p as the response, filling in its instance.
+func Write(c *gin.Context, p Problem) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+	c.Header("Content-Type", ContentType)
+	c.Render(p.Status, render.JSON{Data: p})
+}
+
+// Abort stops the request with an error of status. Routes using Middleware get
+// problem details, others an {"error": detail} body.
+func Abort(c *gin.Context, status int, detail string) {
+	c.Abort()
+	if Enabled(c) {
+		Write(c, New(status, detail))
+		return
+	}
+	c.JSON(status, gin.H{"error": detail})
+}
@@ -0,0 +1,38 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	fail := func(c *gin.Context) { Abort(c, http.StatusNotFound, "Job not found") }
+	r.GET("/legacy", fail)
+	r.GET("/v1/jobs", Middleware(), fail)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/legacy", nil))
+	if w.Code != http.StatusNotFound || w.Body.String() != `{"error":"Job not found"}` {
+		t.Errorf("unexpected legacy response %d %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/jobs", nil))
+	if got := w.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type = %q; want %q", got, ContentType)
+	}
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	want := Problem{Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound, Detail: "Job not found", Instance: "/v1/jobs"}
+	if p.Type != want.Type || p.Title != want.Title || p.Status != want.Status || p.Detail != want.Detail || p.Instance != want.Instance {
+		t.Errorf("problem = %+v; want %+v", p, want)
+	}
+}
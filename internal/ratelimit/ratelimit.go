@@ -0,0 +1,142 @@
+// Package ratelimit implements per-client token bucket rate limiting.
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LimitHeader      = "X-RateLimit-Limit"
+	RemainingHeader  = "X-RateLimit-Remaining"
+	ResetHeader      = "X-RateLimit-Reset"
+	RetryAfterHeader = "Retry-After"
+
+	// idleAfter is how long an untouched bucket is kept. Buckets idle for longer
+	// are full anyway, so dropping them loses nothing.
+	idleAfter = 10 * time.Minute
+	// maxBuckets is the number of buckets above which idle ones are evicted.
+	maxBuckets = 10000
+)
+
+// Limiter hands out tokens from one bucket per client. Each bucket holds up to
+// Burst tokens and refills at Rate tokens per second.
+type Limiter struct {
+	rate  float64
+	burst int
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Result describes the state of a bucket after a request was counted.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token is available. It is zero
+	// when the request was allowed.
+	RetryAfter time.Duration
+}
+
+// New creates a Limiter allowing rate requests per second with bursts of up to
+// burst requests.
+func New(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   burst,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of key, if one is available.
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		l.evictIdle(now)
+		b = &bucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(l.burst), b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	result := Result{Limit: l.burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - b.tokens)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = l.duration(float64(l.burst) - b.tokens)
+	return result
+}
+
+func (l *Limiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rate * float64(time.Second))
+}
+
+// evictIdle drops idle buckets once there are too many. The caller must hold
+// l.mu.
+func (l *Limiter) evictIdle(now time.Time) {
+	if len(l.buckets) < maxBuckets {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware limits requests per client, as identified by keyFunc. Requests for
+// which keyFunc returns an empty key are not limited. Rejected requests get a
+// 429 response; every limited response carries the X-RateLimit-* headers.
+func Middleware(l *Limiter, keyFunc func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := keyFunc(c)
+		if key == "" {
+			c.Next()
+			return
+		}
+		result := l.Allow(key)
+		c.Header(LimitHeader, strconv.Itoa(result.Limit))
+		c.Header(RemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(ResetHeader, strconv.Itoa(ceilSeconds(result.Reset)))
+		if !result.Allowed {
+			c.Header(RetryAfterHeader, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			problem.Abort(c, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+		c.Next()
+	}
+}
+
+// ByIP identifies clients by their IP address.
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := New(1, 2)
+	l.now = func() time.Time { return now }
+
+	if r := l.Allow("a"); !r.Allowed || r.Remaining != 1 {
+		t.Errorf("unexpected first result: %+v", r)
+	}
+	if r := l.Allow("a"); !r.Allowed || r.Remaining != 0 {
+		t.Errorf("unexpected second result: %+v", r)
+	}
+	r := l.Allow("a")
+	if r.Allowed || r.RetryAfter != time.Second || r.Reset != 2*time.Second {
+		t.Errorf("expected the burst to be exhausted, got %+v", r)
+	}
+	if r := l.Allow("b"); !r.Allowed {
+		t.Error("expected buckets to be per key")
+	}
+
+	now = now.Add(time.Second)
+	if r := l.Allow("a"); !r.Allowed {
+		t.Errorf("expected a token after refilling, got %+v", r)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(New(0.001, 1), ByIP))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	if resp.Code != http.StatusOK || resp.Header().Get(LimitHeader) != "1" || resp.Header().Get(RemainingHeader) != "0" {
+		t.Errorf("unexpected first response: %d %v", resp.Code, resp.Header())
+	}
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	if resp.Code != http.StatusTooManyRequests || resp.Header().Get(RetryAfterHeader) == "" {
+		t.Errorf("expected 429 with Retry-After, got %d %v", resp.Code, resp.Header())
+	}
+}
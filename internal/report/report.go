@@ -0,0 +1,199 @@
+// Package report renders analysis outcomes for the command line: as a table,
+// as JSON or as a JUnit XML report that CI systems understand, and checks them
+// against thresholds.
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"web-analyzer/models"
+)
+
+// Format is a command line output format.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+)
+
+// ParseFormat returns the Format named by s. It defaults to a table when s is
+// empty.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatTable:
+		return FormatTable, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatJUnit:
+		return FormatJUnit, nil
+	}
+	return "", fmt.Errorf("unsupported output format %q", s)
+}
+
+// Thresholds are the limits an analysis must stay within to pass. Negative
+// values disable a limit.
+type Thresholds struct {
+	// MaxBrokenLinks is the number of broken links tolerated.
+	MaxBrokenLinks int
+	// MinScore is the lowest acceptable score.
+	MinScore int
+}
+
+// Violations returns a message for every threshold result exceeds.
+func (t Thresholds) Violations(result models.AnalysisResult) []string {
+	var violations []string
+	if t.MaxBrokenLinks >= 0 && result.BrokenLinks > t.MaxBrokenLinks {
+		violations = append(violations, fmt.Sprintf("%d broken links, at most %d allowed", result.BrokenLinks, t.MaxBrokenLinks))
+	}
+	if t.MinScore >= 0 && result.Score < t.MinScore {
+		violations = append(violations, fmt.Sprintf("score %d below the minimum of %d", result.Score, t.MinScore))
+	}
+	return violations
+}
+
+// Case is the outcome of one check, such as the analysis of a page or the
+// check of a link.
+type Case struct {
+	// Name identifies the check, usually by URL.
+	Name string `json:"name"`
+	// Result is the analysis behind the check, if any.
+	Result *models.AnalysisResult `json:"result,omitempty"`
+	// Failures lists the thresholds the check exceeded.
+	Failures []string `json:"failures,omitempty"`
+	// Error is set when the check could not run.
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"-"`
+}
+
+// Passed reports whether the check ran and stayed within its thresholds.
+func (c Case) Passed() bool {
+	return c.Error == "" && len(c.Failures) == 0
+}
+
+// Suite groups the checks of one command run.
+type Suite struct {
+	Name  string `json:"name"`
+	Cases []Case `json:"cases"`
+}
+
+// Counts returns the number of failed and errored checks.
+func (s Suite) Counts() (failures, errors int) {
+	for _, c := range s.Cases {
+		switch {
+		case c.Error != "":
+			errors++
+		case len(c.Failures) > 0:
+			failures++
+		}
+	}
+	return failures, errors
+}
+
+// WriteJSON writes the suite as indented JSON.
+func WriteJSON(w io.Writer, suite Suite) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(suite)
+}
+
+// WriteTable writes one row per check with the given columns, which are
+// extracted from each case by row.
+func WriteTable(w io.Writer, columns []string, suite Suite, row func(Case) []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(append(columns, "RESULT"), "\t"))
+	for _, c := range suite.Cases {
+		outcome := "PASS"
+		switch {
+		case c.Error != "":
+			outcome = "ERROR: " + c.Error
+		case len(c.Failures) > 0:
+			outcome = "FAIL: " + strings.Join(c.Failures, "; ")
+		}
+		fmt.Fprintln(tw, strings.Join(append(row(c), outcome), "\t"))
+	}
+	failures, errors := suite.Counts()
+	fmt.Fprintf(tw, "\n%d checked, %d failed, %d errors\n", len(suite.Cases), failures, errors)
+	return tw.Flush()
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the suite as a JUnit XML report with one test case per
+// check.
+func WriteJUnit(w io.Writer, suite Suite) error {
+	failures, errors := suite.Counts()
+	out := junitSuite{Name: suite.Name, Tests: len(suite.Cases), Failures: failures, Errors: errors}
+	var total time.Duration
+	for _, c := range suite.Cases {
+		total += c.Duration
+		tc := junitCase{Name: c.Name, ClassName: suite.Name, Time: seconds(c.Duration)}
+		switch {
+		case c.Error != "":
+			tc.Error = &junitMessage{Message: c.Error, Body: c.Error}
+		case len(c.Failures) > 0:
+			tc.Failure = &junitMessage{Message: c.Failures[0], Body: strings.Join(c.Failures, "\n")}
+		}
+		out.Cases = append(out.Cases, tc)
+	}
+	out.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitSuites{Suites: []junitSuite{out}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Write renders the suite in the given format. row extracts the table columns
+// of a case.
+func Write(w io.Writer, format Format, columns []string, suite Suite, row func(Case) []string) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, suite)
+	case FormatJUnit:
+		return WriteJUnit(w, suite)
+	}
+	return WriteTable(w, columns, suite, row)
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"web-analyzer/models"
+)
+
+func TestThresholds_Violations(t *testing.T) {
+	result := models.AnalysisResult{BrokenLinks: 2, Score: 70}
+	tests := []struct {
+		name       string
+		thresholds Thresholds
+		want       int
+	}{
+		{"within limits", Thresholds{MaxBrokenLinks: 2, MinScore: 70}, 0},
+		{"too many broken links", Thresholds{MaxBrokenLinks: 0, MinScore: 0}, 1},
+		{"score too low", Thresholds{MaxBrokenLinks: -1, MinScore: 80}, 1},
+		{"both", Thresholds{MaxBrokenLinks: 1, MinScore: 80}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.thresholds.Violations(result); len(got) != tt.want {
+				t.Errorf("expected %d violations, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func suite() Suite {
+	return Suite{Name: "analyze", Cases: []Case{
+		{Name: "https://ok.com"},
+		{Name: "https://broken.com", Failures: []string{"3 broken links, at most 0 allowed"}},
+		{Name: "https://down.com", Error: "fetching page: timeout"},
+	}}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, suite()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var parsed junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	s := parsed.Suites[0]
+	if s.Tests != 3 || s.Failures != 1 || s.Errors != 1 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	if s.Cases[1].Failure == nil || s.Cases[2].Error == nil || s.Cases[0].Failure != nil {
+		t.Errorf("unexpected cases: %+v", s.Cases)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	row := func(c Case) []string { return []string{c.Name} }
+	if err := WriteTable(&buf, []string{"URL"}, suite(), row); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"PASS", "FAIL: 3 broken links", "ERROR: fetching page", "3 checked, 1 failed, 1 errors"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected table to contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != FormatTable {
+		t.Errorf("expected table by default, got %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
@@ -0,0 +1,416 @@
+// Package scheduler re-runs URL analyses and site crawls on cron-like
+// schedules. Schedules are persisted to a JSON file so they survive restarts.
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/workspace"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// maxRuns is the number of past runs kept per schedule.
+	maxRuns = 100
+	// tickInterval is how often due schedules are checked.
+	tickInterval = 15 * time.Second
+)
+
+// ErrNotFound is returned when a schedule does not exist.
+var ErrNotFound = errors.New("schedule not found")
+
+// Kinds of schedules.
+const (
+	// KindAnalysis queues an analysis of the URL.
+	KindAnalysis = "analysis"
+	// KindCrawl crawls the site of the URL.
+	KindCrawl = "crawl"
+)
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// Schedule is a URL analysed, or a site crawled, repeatedly according to a
+// cron expression.
+type Schedule struct {
+	ID        string `json:"id"`
+	Workspace string `json:"workspace"`
+	Kind      string `json:"kind"`
+	URL       string `json:"url"`
+	// MaxPages and MaxDepth bound the crawls of KindCrawl schedules.
+	MaxPages  int        `json:"max_pages,omitempty"`
+	MaxDepth  int        `json:"max_depth,omitempty"`
+	Spec      string     `json:"schedule"`
+	Paused    bool       `json:"paused"`
+	CreatedAt time.Time  `json:"created_at"`
+	NextRun   time.Time  `json:"next_run"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	Runs      []Run      `json:"runs"`
+}
+
+// Run records a single execution of a schedule. Crawls run in the background,
+// so their Pages and Error are filled in once they finish.
+type Run struct {
+	At    time.Time `json:"at"`
+	JobID string    `json:"job_id,omitempty"`
+	Pages int       `json:"pages,omitempty"`
+	Error string    `json:"error,omitempty"`
+}
+
+// Submitter queues an analysis. It is satisfied by *jobs.Manager.
+type Submitter interface {
+	Submit(ctx context.Context, url string) (jobs.Job, error)
+}
+
+// Crawler crawls a site in the workspace of ctx, enforcing its quotas. It is
+// satisfied by *handlers.Handler.
+type Crawler interface {
+	Crawl(ctx context.Context, url string, opts crawler.Options, onPage func(crawler.Page)) ([]crawler.Page, error)
+}
+
+// Scheduler fires due schedules and keeps them persisted.
+type Scheduler struct {
+	path   string
+	submit Submitter
+	now    func() time.Time
+
+	// Crawler runs KindCrawl schedules. Their runs fail while it is nil.
+	Crawler Crawler
+
+	mu        sync.Mutex
+	schedules map[string]*Schedule
+}
+
+// New creates a Scheduler that persists schedules to path, loading any that
+// already exist there.
+func New(path string, submitter Submitter) (*Scheduler, error) {
+	s := &Scheduler{
+		path:      path,
+		submit:    submitter,
+		now:       time.Now,
+		schedules: make(map[string]*Schedule),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ParseSpec validates a cron expression. Standard five field expressions and
+// descriptors such as @hourly, @daily and @every 30m are supported.
+func ParseSpec(spec string) (cron.Schedule, error) {
+	if spec == "@nightly" {
+		spec = "@daily"
+	}
+	return parser.Parse(spec)
+}
+
+// Add registers a new schedule analysing url in a workspace.
+func (s *Scheduler) Add(workspaceID, url, spec string) (Schedule, error) {
+	return s.add(&Schedule{Workspace: workspaceID, Kind: KindAnalysis, URL: url, Spec: spec})
+}
+
+// AddCrawl registers a new schedule crawling the site of url in a workspace.
+// The crawl quota of the workspace is checked again on every run.
+func (s *Scheduler) AddCrawl(workspaceID, url, spec string, opts crawler.Options) (Schedule, error) {
+	return s.add(&Schedule{
+		Workspace: workspaceID,
+		Kind:      KindCrawl,
+		URL:       url,
+		MaxPages:  opts.MaxPages,
+		MaxDepth:  opts.MaxDepth,
+		Spec:      spec,
+	})
+}
+
+func (s *Scheduler) add(schedule *Schedule) (Schedule, error) {
+	sched, err := ParseSpec(schedule.Spec)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("invalid schedule %q: %w", schedule.Spec, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	schedule.ID = newID()
+	schedule.CreatedAt = now
+	schedule.NextRun = sched.Next(now)
+	schedule.Runs = []Run{}
+	s.schedules[schedule.ID] = schedule
+	if err := s.save(); err != nil {
+		delete(s.schedules, schedule.ID)
+		return Schedule{}, err
+	}
+	return *schedule, nil
+}
+
+// List returns the schedules of a workspace ordered by creation time.
+func (s *Scheduler) List(workspaceID string) []Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		if schedule.Workspace == workspaceID {
+			list = append(list, *schedule)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Get returns the schedule of a workspace with the given ID.
+func (s *Scheduler) Get(workspaceID, id string) (Schedule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok || schedule.Workspace != workspaceID {
+		return Schedule{}, false
+	}
+	return *schedule, true
+}
+
+// Pause stops a schedule from firing until it is resumed.
+func (s *Scheduler) Pause(workspaceID, id string) (Schedule, error) {
+	return s.modify(workspaceID, id, func(schedule *Schedule) error {
+		schedule.Paused = true
+		return nil
+	})
+}
+
+// Resume re-enables a paused schedule. Runs missed while paused are skipped.
+func (s *Scheduler) Resume(workspaceID, id string) (Schedule, error) {
+	return s.modify(workspaceID, id, func(schedule *Schedule) error {
+		sched, err := ParseSpec(schedule.Spec)
+		if err != nil {
+			return err
+		}
+		schedule.Paused = false
+		schedule.NextRun = sched.Next(s.now())
+		return nil
+	})
+}
+
+// Delete removes a schedule of a workspace.
+func (s *Scheduler) Delete(workspaceID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok || schedule.Workspace != workspaceID {
+		return ErrNotFound
+	}
+	delete(s.schedules, id)
+	if err := s.save(); err != nil {
+		s.schedules[id] = schedule
+		return err
+	}
+	return nil
+}
+
+func (s *Scheduler) modify(workspaceID, id string, fn func(*Schedule) error) (Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok || schedule.Workspace != workspaceID {
+		return Schedule{}, ErrNotFound
+	}
+	previous := *schedule
+	if err := fn(schedule); err != nil {
+		return Schedule{}, err
+	}
+	if err := s.save(); err != nil {
+		*schedule = previous
+		return Schedule{}, err
+	}
+	return *schedule, nil
+}
+
+// Run fires due schedules until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	s.Tick()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick()
+		}
+	}
+}
+
+// Tick fires every schedule whose next run is due. A schedule that missed
+// several runs, for example while the server was down, fires once.
+//
+// The next run time is advanced and persisted before the analysis is queued,
+// so a restart never fires the same occurrence twice.
+func (s *Scheduler) Tick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var due []*Schedule
+	for _, schedule := range s.schedules {
+		if schedule.Paused || schedule.NextRun.After(now) {
+			continue
+		}
+		sched, err := ParseSpec(schedule.Spec)
+		if err != nil {
+			slog.Error("Invalid stored schedule", "schedule_id", schedule.ID, "error", err)
+			continue
+		}
+		schedule.NextRun = sched.Next(now)
+		due = append(due, schedule)
+	}
+	if len(due) == 0 {
+		return
+	}
+	if err := s.save(); err != nil {
+		slog.Error("Failed to persist schedules, skipping due runs", "error", err)
+		return
+	}
+
+	for _, schedule := range due {
+		run := Run{At: now}
+		ctx := workspace.WithID(context.Background(), schedule.Workspace)
+		ctx = logging.With(ctx, "schedule_id", schedule.ID, "workspace", schedule.Workspace)
+		if schedule.Kind == KindCrawl {
+			if s.Crawler == nil {
+				run.Error = "crawling is not enabled"
+			} else {
+				go s.crawl(ctx, *schedule, now)
+			}
+			s.record(schedule, run)
+			continue
+		}
+		job, err := s.submit.Submit(ctx, schedule.URL)
+		if err != nil {
+			run.Error = err.Error()
+			slog.Error("Scheduled analysis not queued", "schedule_id", schedule.ID, "url", schedule.URL, "error", err)
+		} else {
+			run.JobID = job.ID
+			slog.Info("Scheduled analysis queued", "schedule_id", schedule.ID, "url", schedule.URL, "job_id", job.ID)
+		}
+		s.record(schedule, run)
+	}
+	if err := s.save(); err != nil {
+		slog.Error("Failed to persist schedule runs", "error", err)
+	}
+}
+
+// record appends run to the runs of schedule. The caller must hold s.mu.
+func (s *Scheduler) record(schedule *Schedule, run Run) {
+	schedule.LastRun = &run.At
+	schedule.Runs = append(schedule.Runs, run)
+	if len(schedule.Runs) > maxRuns {
+		schedule.Runs = schedule.Runs[len(schedule.Runs)-maxRuns:]
+	}
+}
+
+// crawl runs a crawl of schedule and fills in the outcome of its run started
+// at.
+func (s *Scheduler) crawl(ctx context.Context, schedule Schedule, at time.Time) {
+	opts := crawler.Options{MaxPages: schedule.MaxPages, MaxDepth: schedule.MaxDepth}
+	pages, err := s.Crawler.Crawl(ctx, schedule.URL, opts, nil)
+	if err != nil {
+		slog.Error("Scheduled crawl failed", "schedule_id", schedule.ID, "url", schedule.URL, "error", err)
+	} else {
+		slog.Info("Scheduled crawl finished", "schedule_id", schedule.ID, "url", schedule.URL, "pages", len(pages))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.schedules[schedule.ID]
+	if !ok {
+		return
+	}
+	// Copies handed out by Get share the runs, so replace them rather than
+	// update them in place.
+	runs := slices.Clone(current.Runs)
+	for i := range runs {
+		if run := &runs[i]; run.At.Equal(at) {
+			run.Pages = len(pages)
+			if err != nil {
+				run.Error = err.Error()
+			}
+		}
+	}
+	current.Runs = runs
+	if err := s.save(); err != nil {
+		slog.Error("Failed to persist schedule runs", "error", err)
+	}
+}
+
+func (s *Scheduler) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading schedules: %w", err)
+	}
+	var list []*Schedule
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("decoding schedules: %w", err)
+	}
+	for _, schedule := range list {
+		// Schedules saved before workspaces existed belong to the default one.
+		if schedule.Workspace == "" {
+			schedule.Workspace = workspace.Default
+		}
+		if schedule.Kind == "" {
+			schedule.Kind = KindAnalysis
+		}
+		s.schedules[schedule.ID] = schedule
+	}
+	return nil
+}
+
+// save writes all schedules to disk atomically. The caller must hold s.mu.
+func (s *Scheduler) save() error {
+	list := make([]*Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		list = append(list, schedule)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("creating schedules directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing schedules: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("writing schedules: %w", err)
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
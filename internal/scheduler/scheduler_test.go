@@ -0,0 +1,211 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"web-analyzer/internal/crawler"
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/workspace"
+)
+
+type mockSubmitter struct {
+	mu   sync.Mutex
+	urls []string
+}
+
+func (m *mockSubmitter) Submit(ctx context.Context, url string) (jobs.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.urls = append(m.urls, url)
+	return jobs.Job{ID: "job-" + url}, nil
+}
+
+type mockCrawler struct {
+	mu   sync.Mutex
+	opts []crawler.Options
+	err  error
+}
+
+func (m *mockCrawler) Crawl(ctx context.Context, url string, opts crawler.Options, onPage func(crawler.Page)) ([]crawler.Page, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.opts = append(m.opts, opts)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []crawler.Page{{URL: url}, {URL: url + "/a", Depth: 1}}, nil
+}
+
+func newTestScheduler(t *testing.T, path string, submitter Submitter, now *time.Time) *Scheduler {
+	t.Helper()
+	s, err := New(path, submitter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestScheduler_FiresDueSchedules(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	submitter := &mockSubmitter{}
+	s := newTestScheduler(t, filepath.Join(t.TempDir(), "schedules.json"), submitter, &now)
+
+	schedule, err := s.Add(workspace.Default, "https://example.com", "@hourly")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC); !schedule.NextRun.Equal(want) {
+		t.Fatalf("expected next run %v, got %v", want, schedule.NextRun)
+	}
+
+	s.Tick()
+	if len(submitter.urls) != 0 {
+		t.Fatalf("expected no run before next run time, got %v", submitter.urls)
+	}
+
+	now = now.Add(31 * time.Minute)
+	s.Tick()
+	s.Tick()
+	if len(submitter.urls) != 1 {
+		t.Fatalf("expected exactly one run, got %d", len(submitter.urls))
+	}
+
+	got, _ := s.Get(workspace.Default, schedule.ID)
+	if len(got.Runs) != 1 || got.Runs[0].JobID != "job-https://example.com" {
+		t.Errorf("expected run to be recorded, got %+v", got.Runs)
+	}
+	if want := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC); !got.NextRun.Equal(want) {
+		t.Errorf("expected next run %v, got %v", want, got.NextRun)
+	}
+}
+
+func TestScheduler_PersistsAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	first := &mockSubmitter{}
+	s := newTestScheduler(t, path, first, &now)
+
+	schedule, err := s.Add(workspace.Default, "https://example.com", "@hourly")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(45 * time.Minute)
+	s.Tick()
+
+	// A restart in the same hour must not fire the occurrence again.
+	second := &mockSubmitter{}
+	restarted := newTestScheduler(t, path, second, &now)
+	restarted.Tick()
+	if len(second.urls) != 0 {
+		t.Fatalf("expected no duplicate run after restart, got %v", second.urls)
+	}
+	if _, ok := restarted.Get(workspace.Default, schedule.ID); !ok {
+		t.Fatal("expected schedule to be loaded after restart")
+	}
+}
+
+func TestScheduler_PauseResumeDelete(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	submitter := &mockSubmitter{}
+	s := newTestScheduler(t, filepath.Join(t.TempDir(), "schedules.json"), submitter, &now)
+
+	schedule, _ := s.Add(workspace.Default, "https://example.com", "@every 10m")
+	if _, err := s.Pause(workspace.Default, schedule.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(time.Hour)
+	s.Tick()
+	if len(submitter.urls) != 0 {
+		t.Fatalf("expected paused schedule not to fire, got %v", submitter.urls)
+	}
+
+	resumed, err := s.Resume(workspace.Default, schedule.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resumed.NextRun.After(now) {
+		t.Errorf("expected missed runs to be skipped on resume, next run %v", resumed.NextRun)
+	}
+
+	if err := s.Delete(workspace.Default, schedule.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Delete(workspace.Default, schedule.ID); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestScheduler_FiresCrawls(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	submitter := &mockSubmitter{}
+	crawls := &mockCrawler{}
+	s := newTestScheduler(t, filepath.Join(t.TempDir(), "schedules.json"), submitter, &now)
+	s.Crawler = crawls
+
+	schedule, err := s.AddCrawl(workspace.Default, "https://example.com", "@hourly", crawler.Options{MaxPages: 20, MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.Kind != KindCrawl || schedule.MaxPages != 20 || schedule.MaxDepth != 2 {
+		t.Fatalf("unexpected schedule: %+v", schedule)
+	}
+
+	now = now.Add(31 * time.Minute)
+	s.Tick()
+	waitForRun := func(check func(Run) bool) Run {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			got, _ := s.Get(workspace.Default, schedule.ID)
+			if n := len(got.Runs); n > 0 && check(got.Runs[n-1]) {
+				return got.Runs[n-1]
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatal("timed out waiting for the crawl to finish")
+		return Run{}
+	}
+	waitForRun(func(r Run) bool { return r.Pages == 2 && r.Error == "" })
+	if len(submitter.urls) != 0 {
+		t.Errorf("expected crawls not to queue analyses, got %v", submitter.urls)
+	}
+	crawls.mu.Lock()
+	if len(crawls.opts) != 1 || crawls.opts[0] != (crawler.Options{MaxPages: 20, MaxDepth: 2}) {
+		t.Errorf("unexpected crawl options: %+v", crawls.opts)
+	}
+	crawls.mu.Unlock()
+
+	// Quota errors of the crawler are recorded on the run.
+	crawls.mu.Lock()
+	crawls.err = workspace.ErrQuotaExceeded
+	crawls.mu.Unlock()
+	now = now.Add(time.Hour)
+	s.Tick()
+	run := waitForRun(func(r Run) bool { return r.Error != "" })
+	if run.Error != workspace.ErrQuotaExceeded.Error() {
+		t.Errorf("expected the quota error, got %q", run.Error)
+	}
+
+	s.Crawler = nil
+	now = now.Add(time.Hour)
+	s.Tick()
+	if run := waitForRun(func(Run) bool { return true }); run.Error != "crawling is not enabled" {
+		t.Errorf("expected crawling to be disabled, got %q", run.Error)
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	for _, spec := range []string{"@hourly", "@nightly", "@every 30m", "0 3 * * *"} {
+		if _, err := ParseSpec(spec); err != nil {
+			t.Errorf("ParseSpec(%q) returned %v", spec, err)
+		}
+	}
+	if _, err := ParseSpec("every day"); err == nil {
+		t.Error("expected invalid spec to fail")
+	}
+}
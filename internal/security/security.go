@@ -0,0 +1,399 @@
+// Package security audits the security headers, cookies, subresources and TLS
+// connection of a fetched page.
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	// minHSTSMaxAge is the smallest HSTS max-age rated good, 180 days.
+	minHSTSMaxAge = 180 * 24 * 60 * 60
+	// expiryWarningDays is the number of days before its expiry from which a
+	// certificate is reported.
+	expiryWarningDays = 30
+)
+
+// Audit audits the final response of a page and its parsed document. pageURL
+// is the URL the response was received from.
+func Audit(resp *http.Response, doc *html.Node, pageURL string) *models.Security {
+	return AuditAt(resp, doc, pageURL, time.Now())
+}
+
+// AuditAt is like Audit and rates the certificate expiry as of now.
+func AuditAt(resp *http.Response, doc *html.Node, pageURL string, now time.Time) *models.Security {
+	u, _ := url.Parse(pageURL)
+	https := u != nil && u.Scheme == "https"
+
+	s := &models.Security{Headers: auditHeaders(resp.Header, https)}
+	for _, cookie := range resp.Cookies() {
+		s.Cookies = append(s.Cookies, auditCookie(cookie, https))
+	}
+	if https {
+		s.MixedContent = MixedContent(doc)
+	}
+	if resp.TLS != nil {
+		s.TLS = auditTLS(resp.TLS, now)
+	}
+	return s
+}
+
+// Issues summarises the problems of an audit in human readable form.
+func Issues(s *models.Security) []string {
+	if s == nil {
+		return nil
+	}
+	var issues, missing []string
+	for _, header := range s.Headers {
+		if header.Status == models.HeaderMissing {
+			missing = append(missing, header.Name)
+		}
+	}
+	if len(missing) > 0 {
+		issues = append(issues, "Missing security headers: "+strings.Join(missing, ", "))
+	}
+	insecure := 0
+	for _, cookie := range s.Cookies {
+		if len(cookie.Issues) > 0 {
+			insecure++
+		}
+	}
+	if insecure > 0 {
+		issues = append(issues, fmt.Sprintf("%d cookies without recommended flags", insecure))
+	}
+	if len(s.MixedContent) > 0 {
+		issues = append(issues, fmt.Sprintf("%d mixed content resources", len(s.MixedContent)))
+	}
+	if s.TLS != nil {
+		issues = append(issues, s.TLS.Issues...)
+	}
+	return issues
+}
+
+func auditHeaders(h http.Header, https bool) []models.SecurityHeader {
+	csp := h.Get("Content-Security-Policy")
+	return []models.SecurityHeader{
+		rate("Strict-Transport-Security", h, func(v string) []string { return hstsIssues(v, https) }),
+		cspHeader(h),
+		frameOptions(h, csp),
+		rate("X-Content-Type-Options", h, func(v string) []string {
+			if !strings.EqualFold(strings.TrimSpace(v), "nosniff") {
+				return []string{`Value should be "nosniff"`}
+			}
+			return nil
+		}),
+		rate("Referrer-Policy", h, referrerIssues),
+		rate("Permissions-Policy", h, permissionsIssues),
+	}
+}
+
+// rate rates a header as missing, or as weak when check finds issues with its
+// value.
+func rate(name string, h http.Header, check func(string) []string) models.SecurityHeader {
+	value := strings.TrimSpace(h.Get(name))
+	header := models.SecurityHeader{Name: name, Value: value, Status: models.HeaderMissing}
+	if value == "" {
+		return header
+	}
+	header.Issues = check(value)
+	header.Status = models.HeaderGood
+	if len(header.Issues) > 0 {
+		header.Status = models.HeaderWeak
+	}
+	return header
+}
+
+func hstsIssues(value string, https bool) []string {
+	var issues []string
+	if !https {
+		issues = append(issues, "Ignored on pages not served over HTTPS")
+	}
+	maxAge := -1
+	includeSubDomains := false
+	for _, directive := range strings.Split(value, ";") {
+		name, val, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if n, err := strconv.Atoi(strings.Trim(strings.TrimSpace(val), `"`)); err == nil {
+				maxAge = n
+			}
+		case "includesubdomains":
+			includeSubDomains = true
+		}
+	}
+	switch {
+	case maxAge < 0:
+		issues = append(issues, "No valid max-age")
+	case maxAge < minHSTSMaxAge:
+		issues = append(issues, "max-age is shorter than 180 days")
+	}
+	if !includeSubDomains {
+		issues = append(issues, "Does not include subdomains")
+	}
+	return issues
+}
+
+func cspHeader(h http.Header) models.SecurityHeader {
+	header := rate("Content-Security-Policy", h, cspIssues)
+	if header.Status == models.HeaderMissing {
+		if reportOnly := strings.TrimSpace(h.Get("Content-Security-Policy-Report-Only")); reportOnly != "" {
+			header.Value = reportOnly
+			header.Status = models.HeaderWeak
+			header.Issues = []string{"Only reported, not enforced"}
+		}
+	}
+	return header
+}
+
+func cspIssues(value string) []string {
+	directives := parseCSP(value)
+	scripts, ok := directives["script-src"]
+	if !ok {
+		scripts, ok = directives["default-src"]
+	}
+	if !ok {
+		return []string{"No script-src or default-src directive"}
+	}
+	var issues []string
+	for _, source := range scripts {
+		switch source {
+		case "'unsafe-inline'":
+			issues = append(issues, "Allows inline scripts ('unsafe-inline')")
+		case "'unsafe-eval'":
+			issues = append(issues, "Allows eval ('unsafe-eval')")
+		case "*", "http:", "https:", "data:":
+			issues = append(issues, fmt.Sprintf("Allows scripts from any %s source", strings.TrimSuffix(source, ":")))
+		}
+	}
+	if _, ok := directives["object-src"]; !ok && !contains(directives["default-src"], "'none'") {
+		issues = append(issues, "No object-src 'none'")
+	}
+	return issues
+}
+
+// parseCSP returns the sources of every directive of a policy.
+func parseCSP(value string) map[string][]string {
+	directives := make(map[string][]string)
+	for _, directive := range strings.Split(value, ";") {
+		fields := strings.Fields(strings.ToLower(directive))
+		if len(fields) == 0 {
+			continue
+		}
+		if _, ok := directives[fields[0]]; !ok {
+			directives[fields[0]] = fields[1:]
+		}
+	}
+	return directives
+}
+
+func contains(sources []string, source string) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// frameOptions rates X-Frame-Options. A CSP with frame-ancestors supersedes it.
+func frameOptions(h http.Header, csp string) models.SecurityHeader {
+	header := rate("X-Frame-Options", h, func(v string) []string {
+		switch strings.ToUpper(v) {
+		case "DENY", "SAMEORIGIN":
+			return nil
+		}
+		if strings.HasPrefix(strings.ToUpper(v), "ALLOW-FROM") {
+			return []string{"ALLOW-FROM is not supported by current browsers, use CSP frame-ancestors"}
+		}
+		return []string{`Value should be "DENY" or "SAMEORIGIN"`}
+	})
+	if header.Status == models.HeaderMissing {
+		if _, ok := parseCSP(csp)["frame-ancestors"]; ok {
+			header.Status = models.HeaderGood
+		}
+	}
+	return header
+}
+
+func referrerIssues(value string) []string {
+	// Browsers use the last policy they support.
+	policies := strings.Split(value, ",")
+	switch policy := strings.ToLower(strings.TrimSpace(policies[len(policies)-1])); policy {
+	case "no-referrer", "same-origin", "strict-origin", "strict-origin-when-cross-origin":
+		return nil
+	case "unsafe-url", "origin", "origin-when-cross-origin", "no-referrer-when-downgrade":
+		return []string{fmt.Sprintf("%q leaks the origin or full URL to other sites", policy)}
+	default:
+		return []string{fmt.Sprintf("Unknown policy %q", policy)}
+	}
+}
+
+func permissionsIssues(value string) []string {
+	var issues []string
+	for _, directive := range strings.Split(value, ",") {
+		feature, allowlist, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok {
+			return []string{"Invalid syntax"}
+		}
+		if strings.TrimSpace(allowlist) == "*" {
+			issues = append(issues, fmt.Sprintf("Allows %s for every origin", strings.TrimSpace(feature)))
+		}
+	}
+	return issues
+}
+
+func auditCookie(c *http.Cookie, https bool) models.CookieAudit {
+	audit := models.CookieAudit{Name: c.Name, Secure: c.Secure, HttpOnly: c.HttpOnly}
+	switch c.SameSite {
+	case http.SameSiteLaxMode:
+		audit.SameSite = "Lax"
+	case http.SameSiteStrictMode:
+		audit.SameSite = "Strict"
+	case http.SameSiteNoneMode:
+		audit.SameSite = "None"
+	}
+	if https && !c.Secure {
+		audit.Issues = append(audit.Issues, "Missing Secure flag")
+	}
+	if !c.HttpOnly {
+		audit.Issues = append(audit.Issues, "Missing HttpOnly flag")
+	}
+	switch {
+	case audit.SameSite == "":
+		audit.Issues = append(audit.Issues, "Missing SameSite attribute")
+	case audit.SameSite == "None" && !c.Secure:
+		audit.Issues = append(audit.Issues, "SameSite=None requires the Secure flag")
+	}
+	return audit
+}
+
+func auditTLS(state *tls.ConnectionState, now time.Time) *models.TLSInfo {
+	info := &models.TLSInfo{
+		Version:     tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+	}
+	if state.Version < tls.VersionTLS12 {
+		info.Issues = append(info.Issues, fmt.Sprintf("Outdated TLS version %s", info.Version))
+	}
+	if len(state.PeerCertificates) == 0 {
+		return info
+	}
+	cert := state.PeerCertificates[0]
+	describeCertificate(info, cert, now)
+	switch {
+	case now.After(cert.NotAfter):
+		info.Issues = append(info.Issues, "TLS certificate has expired")
+	case info.DaysUntilExpiry < expiryWarningDays:
+		info.Issues = append(info.Issues, fmt.Sprintf("TLS certificate expires in %d days", info.DaysUntilExpiry))
+	}
+	return info
+}
+
+// VerificationFailure describes the TLS problem of a fetch that failed because
+// the certificate of the server could not be verified, such as an expired,
+// self-signed or mismatched certificate. It returns nil for other errors.
+func VerificationFailure(err error) *models.TLSInfo {
+	var verr *tls.CertificateVerificationError
+	if !errors.As(err, &verr) {
+		return nil
+	}
+	info := &models.TLSInfo{
+		Issues: []string{"TLS certificate verification failed: " + verr.Err.Error()},
+	}
+	if len(verr.UnverifiedCertificates) > 0 {
+		describeCertificate(info, verr.UnverifiedCertificates[0], time.Now())
+	}
+	return info
+}
+
+func describeCertificate(info *models.TLSInfo, cert *x509.Certificate, now time.Time) {
+	info.CertificateSubject = cert.Subject.CommonName
+	info.CertificateIssuer = cert.Issuer.CommonName
+	info.CertificateExpiry = cert.NotAfter
+	info.DaysUntilExpiry = int(cert.NotAfter.Sub(now).Hours() / 24)
+}
+
+// subresources lists the attributes of the elements that load subresources.
+var subresources = map[string][]string{
+	"script": {"src"},
+	"img":    {"src", "srcset"},
+	"iframe": {"src"},
+	"video":  {"src", "poster"},
+	"audio":  {"src"},
+	"source": {"src", "srcset"},
+	"track":  {"src"},
+	"embed":  {"src"},
+	"object": {"data"},
+}
+
+// MixedContent returns the http:// URLs of the subresources of a document, in
+// document order and without duplicates.
+func MixedContent(doc *html.Node) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if len(ref) >= 7 && strings.EqualFold(ref[:7], "http://") && !seen[ref] {
+			seen[ref] = true
+			urls = append(urls, ref)
+		}
+	}
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			attrs := subresources[n.Data]
+			if n.Data == "link" && isSubresourceLink(n) {
+				attrs = []string{"href"}
+			}
+			for _, attr := range n.Attr {
+				if !contains(attrs, attr.Key) {
+					continue
+				}
+				if attr.Key == "srcset" {
+					for _, candidate := range strings.Split(attr.Val, ",") {
+						if fields := strings.Fields(candidate); len(fields) > 0 {
+							add(fields[0])
+						}
+					}
+					continue
+				}
+				add(attr.Val)
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	if doc != nil {
+		traverse(doc)
+	}
+	return urls
+}
+
+// isSubresourceLink reports whether a <link> element loads a stylesheet, icon or
+// preloaded resource.
+func isSubresourceLink(n *html.Node) bool {
+	for _, attr := range n.Attr {
+		if attr.Key == "rel" {
+			for _, rel := range strings.Fields(strings.ToLower(attr.Val)) {
+				if rel == "stylesheet" || rel == "icon" || rel == "preload" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
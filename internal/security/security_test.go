@@ -0,0 +1,179 @@
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+func parse(t *testing.T, src string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return doc
+}
+
+func header(s *models.Security, name string) models.SecurityHeader {
+	for _, h := range s.Headers {
+		if h.Name == name {
+			return h
+		}
+	}
+	return models.SecurityHeader{}
+}
+
+func TestAudit_Headers(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   string
+	}{
+		{"hsts good", "Strict-Transport-Security", "max-age=31536000; includeSubDomains", models.HeaderGood},
+		{"hsts short", "Strict-Transport-Security", "max-age=3600; includeSubDomains", models.HeaderWeak},
+		{"hsts missing", "Strict-Transport-Security", "", models.HeaderMissing},
+		{"csp good", "Content-Security-Policy", "default-src 'self'; object-src 'none'", models.HeaderGood},
+		{"csp unsafe inline", "Content-Security-Policy", "script-src 'self' 'unsafe-inline'; object-src 'none'", models.HeaderWeak},
+		{"csp without scripts", "Content-Security-Policy", "img-src 'self'", models.HeaderWeak},
+		{"xfo deny", "X-Frame-Options", "DENY", models.HeaderGood},
+		{"xfo allow-from", "X-Frame-Options", "ALLOW-FROM https://example.com", models.HeaderWeak},
+		{"nosniff", "X-Content-Type-Options", "nosniff", models.HeaderGood},
+		{"referrer strict", "Referrer-Policy", "no-referrer, strict-origin-when-cross-origin", models.HeaderGood},
+		{"referrer unsafe", "Referrer-Policy", "unsafe-url", models.HeaderWeak},
+		{"permissions", "Permissions-Policy", "camera=(), geolocation=(self)", models.HeaderGood},
+		{"permissions wildcard", "Permissions-Policy", "camera=*", models.HeaderWeak},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.value != "" {
+				resp.Header.Set(tt.header, tt.value)
+			}
+			got := header(Audit(resp, parse(t, ""), "https://example.com/"), tt.header)
+			if got.Status != tt.want {
+				t.Errorf("expected %s, got %s (%v)", tt.want, got.Status, got.Issues)
+			}
+		})
+	}
+}
+
+func TestAudit_FrameAncestorsSupersedesFrameOptions(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Content-Security-Policy": {"default-src 'self'; frame-ancestors 'none'"}}}
+	if got := header(Audit(resp, nil, "https://example.com/"), "X-Frame-Options"); got.Status != models.HeaderGood {
+		t.Errorf("expected frame-ancestors to satisfy X-Frame-Options, got %+v", got)
+	}
+}
+
+func TestAudit_Cookies(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Set-Cookie": {
+		"session=1; Secure; HttpOnly; SameSite=Strict",
+		"tracking=2; SameSite=None",
+	}}}
+	s := Audit(resp, nil, "https://example.com/")
+	if len(s.Cookies) != 2 {
+		t.Fatalf("expected 2 cookies, got %+v", s.Cookies)
+	}
+	if len(s.Cookies[0].Issues) != 0 || s.Cookies[0].SameSite != "Strict" {
+		t.Errorf("expected a secure session cookie, got %+v", s.Cookies[0])
+	}
+	want := []string{"Missing Secure flag", "Missing HttpOnly flag", "SameSite=None requires the Secure flag"}
+	if !reflect.DeepEqual(s.Cookies[1].Issues, want) {
+		t.Errorf("expected issues %v, got %v", want, s.Cookies[1].Issues)
+	}
+}
+
+func TestAudit_MixedContent(t *testing.T) {
+	doc := parse(t, `
+		<link rel="stylesheet" href="http://cdn.example.com/site.css">
+		<script src="https://cdn.example.com/app.js"></script>
+		<img src="/logo.png" srcset="http://cdn.example.com/logo-2x.png 2x">
+		<a href="http://example.org/">Not a subresource</a>
+		<iframe src="HTTP://widgets.example.com/"></iframe>`)
+	resp := &http.Response{Header: http.Header{}}
+
+	got := Audit(resp, doc, "https://example.com/").MixedContent
+	want := []string{"http://cdn.example.com/site.css", "http://cdn.example.com/logo-2x.png", "HTTP://widgets.example.com/"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := Audit(resp, doc, "http://example.com/").MixedContent; got != nil {
+		t.Errorf("expected no mixed content on an http page, got %v", got)
+	}
+}
+
+func TestAudit_TLS(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	resp := &http.Response{Header: http.Header{}, TLS: &tls.ConnectionState{
+		Version:     tls.VersionTLS11,
+		CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		PeerCertificates: []*x509.Certificate{{
+			Subject:  pkix.Name{CommonName: "example.com"},
+			Issuer:   pkix.Name{CommonName: "Example CA"},
+			NotAfter: now.Add(10 * 24 * time.Hour),
+		}},
+	}}
+
+	info := AuditAt(resp, nil, "https://example.com/", now).TLS
+	if info == nil || info.Version != "TLS 1.1" || info.DaysUntilExpiry != 10 || info.CertificateSubject != "example.com" {
+		t.Fatalf("unexpected TLS info: %+v", info)
+	}
+	want := []string{"Outdated TLS version TLS 1.1", "TLS certificate expires in 10 days"}
+	if !reflect.DeepEqual(info.Issues, want) {
+		t.Errorf("expected issues %v, got %v", want, info.Issues)
+	}
+}
+
+func TestIssues(t *testing.T) {
+	resp := &http.Response{Header: http.Header{
+		"Strict-Transport-Security": {"max-age=31536000; includeSubDomains"},
+		"Set-Cookie":                {"id=1"},
+	}}
+	got := Issues(Audit(resp, parse(t, `<script src="http://example.com/a.js"></script>`), "https://example.com/"))
+	want := []string{
+		"Missing security headers: Content-Security-Policy, X-Frame-Options, X-Content-Type-Options, Referrer-Policy, Permissions-Policy",
+		"1 cookies without recommended flags",
+		"1 mixed content resources",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestVerificationFailure(t *testing.T) {
+	if VerificationFailure(errors.New("dial tcp: no such host")) != nil {
+		t.Error("expected no TLS details for other errors")
+	}
+
+	expiry := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := fmt.Errorf("fetching page: %w", &tls.CertificateVerificationError{
+		UnverifiedCertificates: []*x509.Certificate{{
+			Subject:  pkix.Name{CommonName: "example.com"},
+			Issuer:   pkix.Name{CommonName: "Example CA"},
+			NotAfter: expiry,
+		}},
+		Err: x509.UnknownAuthorityError{},
+	})
+	info := VerificationFailure(err)
+	if info == nil {
+		t.Fatal("expected TLS details")
+	}
+	if info.CertificateSubject != "example.com" || info.CertificateIssuer != "Example CA" || !info.CertificateExpiry.Equal(expiry) {
+		t.Errorf("unexpected certificate details: %+v", info)
+	}
+	if len(info.Issues) != 1 || !strings.HasPrefix(info.Issues[0], "TLS certificate verification failed: x509: certificate signed by unknown authority") {
+		t.Errorf("unexpected issues: %v", info.Issues)
+	}
+}
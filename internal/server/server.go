@@ -1,11 +1,21 @@
 package server
 
 import (
+	"html/template"
 	"log/slog"
 	"net/http"
+
 	"web-analyzer/handlers"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/logging"
+	"web-analyzer/internal/metrics"
+	"web-analyzer/internal/problem"
+	"web-analyzer/internal/ratelimit"
+	"web-analyzer/internal/tracing"
+	"web-analyzer/templates"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func Start(h *handlers.Handler) error {
@@ -14,21 +24,134 @@ func Start(h *handlers.Handler) error {
 }
 
 func SetupRouter(h *handlers.Handler) *gin.Engine {
-	r := gin.Default()
-	setupPprof(r)
+	r := gin.New()
+	// gin trusts X-Forwarded-For from everyone by default, which lets clients
+	// pick the IP they are rate limited by. An invalid list trusts no one.
+	if err := r.SetTrustedProxies(h.TrustedProxies); err != nil {
+		slog.Error("Invalid trusted proxies, ignoring X-Forwarded-For", "error", err)
+		r.SetTrustedProxies(nil)
+	}
+	r.Use(
+		otelgin.Middleware(tracing.ServiceName),
+		logging.RequestID(),
+		logging.AccessLog(),
+		logging.Recovery(),
+		metrics.Middleware(),
+	)
+	// URLs are passed as percent-encoded path segments, so route on the raw path.
+	r.UseRawPath = true
+	r.UnescapePathValues = true
+
+	// Probes and metrics are scraped by infrastructure and stay unauthenticated.
+	r.GET("/healthz", h.HealthzHandler)
+	r.GET("/readyz", h.ReadyzHandler)
+	r.GET("/version", h.VersionHandler)
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	r.GET("/openapi.json", h.OpenAPIHandler)
+
+	var ipLimit []gin.HandlerFunc
+	if h.IPLimiter != nil {
+		ipLimit = append(ipLimit, ratelimit.Middleware(h.IPLimiter, ratelimit.ByIP))
+	}
+	api := r.Group("/", ipLimit...)
+	analyze := api.Group("/", requireScope(h, auth.ScopeAnalyze)...)
+	read := api.Group("/", requireScope(h, auth.ScopeRead)...)
+	admin := api.Group("/", requireScope(h, auth.ScopeAdmin)...)
+	superadmin := api.Group("/", requireScope(h, auth.ScopeSuperAdmin)...)
+
+	analyze.POST("/analyze", h.AnalyzeHandler)
+	analyze.POST("/analyze/batch", h.AnalyzeBatchHandler)
+	analyze.POST("/analyze/html", h.AnalyzeHTMLHandler)
+	read.GET("/batches/:id", h.BatchHandler)
+	read.GET("/jobs/:id", h.JobHandler)
+	read.GET("/jobs/:id/events", h.JobEventsHandler)
+	read.GET("/status", h.StatusHandler)
+	read.GET("/urls", h.UrlsHandler)
+	read.GET("/urls/:url/history", h.HistoryHandler)
+	read.GET("/urls/:url/diff", h.DiffHandler)
+	read.GET("/export", h.ExportHandler)
+	read.GET("/workspace", h.WorkspaceHandler)
+
+	analyze.POST("/schedules", h.CreateScheduleHandler)
+	read.GET("/schedules", h.SchedulesHandler)
+	read.GET("/schedules/:id", h.ScheduleHandler)
+	analyze.POST("/schedules/:id/pause", h.PauseScheduleHandler)
+	analyze.POST("/schedules/:id/resume", h.ResumeScheduleHandler)
+	analyze.DELETE("/schedules/:id", h.DeleteScheduleHandler)
+
+	admin.POST("/webhooks", h.CreateWebhookHandler)
+	admin.GET("/webhooks", h.WebhooksHandler)
+	admin.GET("/webhooks/deliveries", h.WebhookDeliveriesHandler)
+	admin.DELETE("/webhooks/:id", h.DeleteWebhookHandler)
+
+	// Workspace admins manage the keys of their own workspace, see
+	// auth.Key.Manages. The log level and quotas affect every workspace.
+	admin.POST("/admin/keys", h.CreateKeyHandler)
+	admin.GET("/admin/keys", h.KeysHandler)
+	admin.DELETE("/admin/keys/:id", h.RevokeKeyHandler)
+	superadmin.GET("/log-level", h.LogLevelHandler)
+	superadmin.PUT("/log-level", h.SetLogLevelHandler)
+	superadmin.GET("/admin/workspaces", h.WorkspacesHandler)
+	superadmin.PUT("/admin/workspaces/:id/quota", h.SetQuotaHandler)
+
+	// The versioned API reports errors as problem details, including those of
+	// the rate limit and authentication middleware.
+	v1 := r.Group("/v1", append([]gin.HandlerFunc{problem.Middleware()}, ipLimit...)...)
+	for _, route := range h.V1Routes() {
+		chain := append(requireScope(h, route.Scope), route.Handler)
+		v1.Handle(route.Method, route.Path, chain...)
+	}
 
-	r.POST("/analyze", h.AnalyzeHandler)
-	r.GET("/status", h.StatusHandler)
-	r.GET("/urls", h.UrlsHandler)
+	setupUI(r, api, h)
 
 	return r
 }
 
-func setupPprof(router *gin.Engine) {
-	go func() {
-		err := http.ListenAndServe("localhost:6060", nil)
-		if err != nil {
-			slog.Error("pprof server failed", "error", err)
-		}
-	}()
+// setupUI registers the dashboard. Its pages authenticate with the API key
+// cookie set by the sign in page and redirect there when it is missing.
+func setupUI(r *gin.Engine, api *gin.RouterGroup, h *handlers.Handler) {
+	r.SetHTMLTemplate(template.Must(templates.Parse()))
+	r.StaticFS("/ui/static", http.FS(templates.Static()))
+	r.GET("/", func(c *gin.Context) { c.Redirect(http.StatusFound, "/ui/") })
+
+	ui := api.Group("/ui")
+	ui.GET("/login", h.UILoginPageHandler)
+	ui.POST("/login", h.UILoginHandler)
+	ui.POST("/logout", h.UILogoutHandler)
+
+	read := ui.Group("/", requireUIScope(h, auth.ScopeRead)...)
+	analyze := ui.Group("/", requireUIScope(h, auth.ScopeAnalyze)...)
+	read.GET("/", h.UIHandler)
+	read.GET("/jobs", h.UIJobsHandler)
+	read.GET("/result", h.UIResultHandler)
+	read.GET("/history", h.UIHistoryHandler)
+	read.GET("/diff", h.UIDiffHandler)
+	analyze.POST("/analyze", h.UISubmitHandler)
+}
+
+// requireScope returns the middleware that authenticates a request for scope
+// and applies the per-key rate limit. It is empty when authentication is
+// disabled.
+func requireScope(h *handlers.Handler, scope auth.Scope) []gin.HandlerFunc {
+	if h.Auth == nil {
+		return nil
+	}
+	return withKeyLimit(h, auth.Middleware(h.Auth, scope))
+}
+
+// requireUIScope is like requireScope for dashboard pages.
+func requireUIScope(h *handlers.Handler, scope auth.Scope) []gin.HandlerFunc {
+	if h.Auth == nil {
+		return nil
+	}
+	return withKeyLimit(h, auth.RedirectMiddleware(h.Auth, scope, "/ui/login"))
+}
+
+func withKeyLimit(h *handlers.Handler, authenticate gin.HandlerFunc) []gin.HandlerFunc {
+	chain := []gin.HandlerFunc{authenticate}
+	if h.KeyLimiter != nil {
+		chain = append(chain, ratelimit.Middleware(h.KeyLimiter, auth.ByKey))
+	}
+	return chain
 }
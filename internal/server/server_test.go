@@ -1,11 +1,21 @@
 package server_test
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 	"web-analyzer/handlers"
+	"web-analyzer/internal/analyzer"
+	"web-analyzer/internal/auth"
+	"web-analyzer/internal/ratelimit"
 	"web-analyzer/internal/server"
+	"web-analyzer/internal/workspace"
 
 	"web-analyzer/models"
 
@@ -16,12 +26,32 @@ import (
 // MockAnalyzerService for testing
 type MockAnalyzerService struct{}
 
-func (m *MockAnalyzerService) AnalyzePage(url string) {}
+func (m *MockAnalyzerService) AnalyzePage(ctx context.Context, url string) error { return nil }
 
 func (m *MockAnalyzerService) GetAnalysis(url string) (models.AnalysisResult, bool) {
 	return models.AnalysisResult{}, false
 }
 
+func (m *MockAnalyzerService) ListAnalyses() []models.AnalysisResult {
+	return nil
+}
+
+func (m *MockAnalyzerService) GetHistory(url string) []models.Snapshot {
+	return nil
+}
+
+func (m *MockAnalyzerService) GetSubmittedUrls() []string {
+	return nil
+}
+
+func (m *MockAnalyzerService) ListUrls() []models.URLEntry {
+	return nil
+}
+
+func (m *MockAnalyzerService) AnalyzeDocument(ctx context.Context, r io.Reader, baseURL string) (models.AnalysisResult, error) {
+	return models.AnalysisResult{}, nil
+}
+
 func setupTestHandler() *handlers.Handler {
 	service := &MockAnalyzerService{}
 	return handlers.NewHandler(service)
@@ -51,3 +81,242 @@ func TestSetupRouter(t *testing.T) {
 	router.ServeHTTP(w3, req3)
 	assert.Equal(t, http.StatusOK, w3.Code)
 }
+
+func TestSetupRouter_NoDebugEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := server.SetupRouter(setupTestHandler())
+
+	req, _ := http.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetupRouter_RequiresAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := setupTestHandler()
+	h.Auth, _ = auth.NewStore("")
+	_, readKey, _ := h.Auth.Issue("reader", workspace.Default, []auth.Scope{auth.ScopeRead})
+	_, adminKey, _ := h.Auth.Issue("admin", workspace.Default, []auth.Scope{auth.ScopeAdmin})
+	_, superKey, _ := h.Auth.Issue("root", workspace.Default, []auth.Scope{auth.ScopeSuperAdmin})
+	router := server.SetupRouter(h)
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{"probe without key", "/healthz", "", http.StatusOK},
+		{"missing key", "/urls", "", http.StatusUnauthorized},
+		{"invalid key", "/urls", "Bearer wa_invalid", http.StatusUnauthorized},
+		{"read scope", "/urls", "Bearer " + readKey, http.StatusOK},
+		{"admin route with read scope", "/webhooks", "Bearer " + readKey, http.StatusForbidden},
+		{"admin route with admin scope", "/webhooks", "Bearer " + adminKey, http.StatusOK},
+		{"superadmin route with admin scope", "/admin/workspaces", "Bearer " + adminKey, http.StatusForbidden},
+		{"log level with admin scope", "/log-level", "Bearer " + adminKey, http.StatusForbidden},
+		{"superadmin route with superadmin scope", "/admin/workspaces", "Bearer " + superKey, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestSetupRouter_RateLimitsPerKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := setupTestHandler()
+	h.Auth, _ = auth.NewStore("")
+	h.KeyLimiter = ratelimit.New(0.001, 1)
+	_, key, _ := h.Auth.Issue("reader", workspace.Default, []auth.Scope{auth.ScopeRead})
+	router := server.SetupRouter(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/urls", nil)
+	req.Header.Set(auth.APIKeyHeader, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get(ratelimit.LimitHeader))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get(ratelimit.RetryAfterHeader))
+}
+
+func TestSetupRouter_RateLimitsPerIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		proxies []string
+		want    int
+	}{
+		// Without trusted proxies X-Forwarded-For is ignored, so spoofing it
+		// does not yield a fresh bucket.
+		{"spoofed header", nil, http.StatusTooManyRequests},
+		{"trusted proxy", []string{"192.0.2.0/24"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := setupTestHandler()
+			h.IPLimiter = ratelimit.New(0.001, 1)
+			h.TrustedProxies = tt.proxies
+			router := server.SetupRouter(h)
+
+			var w *httptest.ResponseRecorder
+			for _, forwarded := range []string{"198.51.100.1", "198.51.100.2"} {
+				req, _ := http.NewRequest(http.MethodGet, "/urls", nil)
+				req.RemoteAddr = "192.0.2.10:1234"
+				req.Header.Set("X-Forwarded-For", forwarded)
+				w = httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+			}
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+// urlService records the URLs it analyses, like the storage of a workspace.
+type urlService struct {
+	MockAnalyzerService
+	mu   sync.Mutex
+	urls []string
+}
+
+func (s *urlService) AnalyzePage(ctx context.Context, url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.urls = append(s.urls, url)
+	return nil
+}
+
+func (s *urlService) GetSubmittedUrls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.urls...)
+}
+
+func (s *urlService) ListUrls() []models.URLEntry {
+	var entries []models.URLEntry
+	for _, url := range s.GetSubmittedUrls() {
+		entries = append(entries, models.URLEntry{URL: url, SubmitCount: 1})
+	}
+	return entries
+}
+
+func TestSetupRouter_IsolatesWorkspaces(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewWorkspaceHandler(workspace.NewRegistry(func(string) analyzer.AnalyzerService {
+		return &urlService{}
+	}, workspace.Quota{AnalysesPerDay: 1}))
+	h.Auth, _ = auth.NewStore("")
+	_, keyA, _ := h.Auth.Issue("a", "team-a", []auth.Scope{auth.ScopeAnalyze, auth.ScopeRead})
+	_, keyB, _ := h.Auth.Issue("b", "team-b", []auth.Scope{auth.ScopeRead})
+	router := server.SetupRouter(h)
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(auth.APIKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/analyze", keyA, `{"url": "https://example.com"}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var queued struct {
+		JobID string `json:"job_id"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &queued))
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(do(http.MethodGet, "/urls", keyA, "").Body.String(), "https://example.com")
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NotContains(t, do(http.MethodGet, "/urls", keyB, "").Body.String(), "https://example.com")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/jobs/"+queued.JobID, keyA, "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodGet, "/jobs/"+queued.JobID, keyB, "").Code)
+
+	w = do(http.MethodPost, "/analyze", keyA, `{"url": "https://example.org"}`)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, do(http.MethodGet, "/workspace", keyA, "").Body.String(), `"analyses":1`)
+}
+
+func TestSetupRouter_DashboardSignIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := setupTestHandler()
+	h.Auth, _ = auth.NewStore("")
+	_, key, _ := h.Auth.Issue("reader", workspace.Default, []auth.Scope{auth.ScopeRead})
+	router := server.SetupRouter(h)
+
+	do := func(method, path string, cookie *http.Cookie, form string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/ui/", nil, "")
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/ui/login", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/ui/login", nil, "").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/ui/static/style.css", nil, "").Code)
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/ui/login", nil, "key=wa_invalid").Code)
+	w = do(http.MethodPost, "/ui/login", nil, "key="+key)
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	cookies := w.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+		assert.False(t, cookies[0].Secure)
+	}
+	cookie := cookies[0]
+
+	h.SecureCookies = true
+	if cookies := do(http.MethodPost, "/ui/login", nil, "key="+key).Result().Cookies(); assert.Len(t, cookies, 1) {
+		assert.True(t, cookies[0].Secure)
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/ui/", cookie, "").Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/ui/analyze", cookie, "urls=https://example.com").Code)
+	// The cookie only authenticates dashboard pages, not the API.
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/urls", cookie, "").Code)
+}
+
+func TestSetupRouter_V1ReportsProblems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := setupTestHandler()
+	h.Auth, _ = auth.NewStore("")
+	router := server.SetupRouter(h)
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/urls", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"status":401`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/urls", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, `{"error":"Missing or invalid API key"}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"securitySchemes"`)
+}
@@ -2,36 +2,78 @@ package services
 
 import (
 	"log/slog"
-)
-
-var submittedUrls = make(map[string]struct{})
+	"sort"
+	"sync"
+	"time"
 
-func GetSubmittedUrls() []string {
-	urls := make([]string, 0, len(submittedUrls))
-	for url := range submittedUrls {
-		urls = append(urls, url)
-	}
-	return urls
-}
+	"web-analyzer/models"
+)
 
 // NewStorage creates and returns a new instance of Storage.
-
 func NewStorage() *Storage {
+	return &Storage{entries: make(map[string]*models.URLEntry), now: time.Now}
+}
 
-	return &Storage{}
+// Storage records the URLs submitted for analysis along with the outcome of
+// their latest analysis. Each instance keeps its own URLs, so separate
+// workspaces never see each other's submissions.
+type Storage struct {
+	now func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]*models.URLEntry
+}
 
+// AddSubmittedUrl implements analyzer.Storage. It counts a new submission of
+// url.
+func (s *Storage) AddSubmittedUrl(url string) {
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.entries[url]
+	if !ok {
+		entry = &models.URLEntry{URL: url, FirstSubmittedAt: now}
+		s.entries[url] = entry
+	}
+	entry.SubmitCount++
+	entry.LastSubmittedAt = now
+	s.mu.Unlock()
+	if !ok {
+		slog.Info("New URL added to submissions", "url", url)
+	}
 }
 
-// Storage represents a storage structure.
+// UpdateUrl implements analyzer.Storage. It applies fn to the entry of a
+// submitted URL and does nothing for unknown URLs.
+func (s *Storage) UpdateUrl(url string, fn func(*models.URLEntry)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.entries[url]; ok {
+		fn(entry)
+	}
+}
 
-type Storage struct {
-	submittedUrls map[string]bool
+// GetSubmittedUrls returns the submitted URLs in alphabetical order.
+func (s *Storage) GetSubmittedUrls() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	urls := make([]string, 0, len(s.entries))
+	for url := range s.entries {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
 }
 
-// AddSubmittedUrl implements analyzer.Storage.
-func (s Storage) AddSubmittedUrl(url string) {
-	submittedUrls[url] = struct{}{}
-	slog.Info("New URL added to submissions", "url", url)
+// ListUrls returns the entries of all submitted URLs in alphabetical order.
+func (s *Storage) ListUrls() []models.URLEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]models.URLEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].URL < entries[j].URL })
+	return entries
 }
 
 type LinkChecker interface {
@@ -39,14 +81,10 @@ type LinkChecker interface {
 }
 
 type Analyzer struct {
-	Storage     Storage
+	Storage     *Storage
 	LinkChecker LinkChecker
 }
 
 func (a *Analyzer) GetSubmittedUrls() []string {
-	urls := make([]string, 0, len(submittedUrls))
-	for url := range submittedUrls {
-		urls = append(urls, url)
-	}
-	return urls
+	return a.Storage.GetSubmittedUrls()
 }
@@ -1,7 +1,11 @@
 package services
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+
+	"web-analyzer/models"
 )
 
 func TestAddSubmittedUrl(t *testing.T) {
@@ -10,7 +14,7 @@ func TestAddSubmittedUrl(t *testing.T) {
 	url := "http://example.com"
 	storage.AddSubmittedUrl(url)
 
-	submittedUrls := GetSubmittedUrls()
+	submittedUrls := storage.GetSubmittedUrls()
 	if len(submittedUrls) != 1 {
 		t.Errorf("expected 1 URL, got %d", len(submittedUrls))
 	}
@@ -29,7 +33,7 @@ func TestGetSubmittedUrls(t *testing.T) {
 	storage.AddSubmittedUrl(url1)
 	storage.AddSubmittedUrl(url2)
 
-	submittedUrls := GetSubmittedUrls()
+	submittedUrls := storage.GetSubmittedUrls()
 	if len(submittedUrls) != 2 {
 		t.Errorf("expected 2 URLs, got %d", len(submittedUrls))
 	}
@@ -48,7 +52,7 @@ func TestGetSubmittedUrls(t *testing.T) {
 
 func TestAnalyzer_GetSubmittedUrls(t *testing.T) {
 	storage := NewStorage()
-	analyzer := Analyzer{Storage: *storage}
+	analyzer := Analyzer{Storage: storage}
 
 	url1 := "http://example1.com"
 	url2 := "http://example2.com"
@@ -72,3 +76,60 @@ func TestAnalyzer_GetSubmittedUrls(t *testing.T) {
 		}
 	}
 }
+
+func TestStorage_Isolated(t *testing.T) {
+	first := NewStorage()
+	second := NewStorage()
+
+	first.AddSubmittedUrl("http://example.com")
+
+	if urls := second.GetSubmittedUrls(); len(urls) != 0 {
+		t.Errorf("expected storages not to share URLs, got %v", urls)
+	}
+}
+
+func TestStorage_ListUrls(t *testing.T) {
+	storage := NewStorage()
+	storage.AddSubmittedUrl("http://example.com")
+	storage.AddSubmittedUrl("http://example.com")
+	storage.UpdateUrl("http://example.com", func(entry *models.URLEntry) {
+		entry.LastJobID = "job-1"
+		entry.LastScore = 80
+	})
+	storage.UpdateUrl("http://unknown.com", func(entry *models.URLEntry) {
+		t.Error("expected unknown URLs not to be updated")
+	})
+
+	entries := storage.ListUrls()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.SubmitCount != 2 || entry.LastJobID != "job-1" || entry.LastScore != 80 {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if entry.FirstSubmittedAt.IsZero() || entry.LastSubmittedAt.Before(entry.FirstSubmittedAt) {
+		t.Errorf("unexpected submission times: %+v", entry)
+	}
+}
+
+// TestStorage_ConcurrentWrites exercises the storage the way the job workers
+// do; run with -race.
+func TestStorage_ConcurrentWrites(t *testing.T) {
+	storage := NewStorage()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("https://example.com/%d", i%3)
+			storage.AddSubmittedUrl(url)
+			storage.UpdateUrl(url, func(e *models.URLEntry) { e.LastStatus = "Completed" })
+			storage.ListUrls()
+		}(i)
+	}
+	wg.Wait()
+	if got := len(storage.GetSubmittedUrls()); got != 3 {
+		t.Errorf("expected 3 URLs, got %d", got)
+	}
+}
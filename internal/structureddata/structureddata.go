@@ -0,0 +1,509 @@
+// Package structureddata extracts the schema.org entities a page describes in
+// JSON-LD, microdata and RDFa, and validates the required properties of common
+// types.
+package structureddata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"web-analyzer/internal/htmlutil"
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+// required lists the properties every entity of a type needs. Each entry is a
+// group of alternatives of which at least one must be present.
+var required = map[string][][]string{
+	"Article":        {{"headline"}, {"author"}, {"datePublished"}},
+	"Product":        {{"name"}, {"offers", "review", "aggregateRating"}},
+	"BreadcrumbList": {{"itemListElement"}},
+	"ListItem":       {{"position"}, {"name", "item"}},
+	"Organization":   {{"name"}, {"url"}},
+}
+
+// subtypes maps common subtypes to the type whose requirements they share.
+var subtypes = map[string]string{
+	"NewsArticle":             "Article",
+	"BlogPosting":             "Article",
+	"TechArticle":             "Article",
+	"ScholarlyArticle":        "Article",
+	"Report":                  "Article",
+	"Corporation":             "Organization",
+	"EducationalOrganization": "Organization",
+	"LocalBusiness":           "Organization",
+	"NewsMediaOrganization":   "Organization",
+	"NGO":                     "Organization",
+	"OnlineStore":             "Organization",
+}
+
+// item is an entity as parsed from any of the formats.
+type item struct {
+	types []string
+	id    string
+	props map[string][]value
+}
+
+// value is a property value, either text or a nested entity.
+type value struct {
+	text string
+	item *item
+}
+
+func newItem(types []string) *item {
+	return &item{types: types, props: make(map[string][]value)}
+}
+
+func (it *item) add(names []string, v value) {
+	for _, name := range names {
+		name = localName(name)
+		it.props[name] = append(it.props[name], v)
+	}
+}
+
+// Extract returns the entities of a document and the errors met parsing them.
+// Entities are listed by format, JSON-LD first, in document order.
+func Extract(doc *html.Node) *models.StructuredData {
+	data := &models.StructuredData{Entities: []models.Entity{}}
+	if doc == nil {
+		return data
+	}
+	for _, format := range []struct {
+		name    string
+		extract func(*html.Node) ([]*item, []string)
+	}{
+		{models.FormatJSONLD, extractJSONLD},
+		{models.FormatMicrodata, microdata.extract},
+		{models.FormatRDFa, rdfa.extract},
+	} {
+		items, errs := format.extract(doc)
+		for _, it := range items {
+			data.Entities = append(data.Entities, entity(format.name, it))
+		}
+		data.Errors = append(data.Errors, errs...)
+	}
+	return data
+}
+
+// Issues summarises the problems of the structured data of a page in human
+// readable form.
+func Issues(data *models.StructuredData) []string {
+	if data == nil {
+		return nil
+	}
+	var issues []string
+	invalid := 0
+	for _, e := range data.Entities {
+		if len(e.Issues) > 0 {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		issues = append(issues, fmt.Sprintf("%d structured data entities missing required properties", invalid))
+	}
+	if len(data.Errors) > 0 {
+		issues = append(issues, fmt.Sprintf("%d structured data errors", len(data.Errors)))
+	}
+	return issues
+}
+
+func entity(format string, it *item) models.Entity {
+	e := models.Entity{Format: format, ID: it.id, Properties: make(map[string][]string, len(it.props))}
+	if len(it.types) > 0 {
+		e.Type = it.types[0]
+	}
+	for name, values := range it.props {
+		for _, v := range values {
+			e.Properties[name] = append(e.Properties[name], v.String())
+		}
+	}
+	e.Issues = validate(it, "")
+	return e
+}
+
+// String returns the text of a value, or a name identifying a nested entity.
+func (v value) String() string {
+	if v.item == nil {
+		return v.text
+	}
+	for _, name := range []string{"name", "headline"} {
+		if values := v.item.props[name]; len(values) > 0 {
+			return values[0].String()
+		}
+	}
+	if v.item.id != "" {
+		return v.item.id
+	}
+	if len(v.item.types) > 0 {
+		return v.item.types[0]
+	}
+	return ""
+}
+
+// validate returns the required properties missing from an entity and the
+// entities nested in it. Issues of nested entities are prefixed with the path
+// of the property they are the value of, e.g. "itemListElement[2]: ".
+func validate(it *item, path string) []string {
+	var issues []string
+	for _, group := range requirements(it.types) {
+		if !hasAny(it, group) {
+			issues = append(issues, path+missing(group))
+		}
+	}
+
+	names := make([]string, 0, len(it.props))
+	for name := range it.props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := it.props[name]
+		for i, v := range values {
+			if v.item == nil {
+				continue
+			}
+			prefix := path + name
+			if len(values) > 1 {
+				prefix += "[" + strconv.Itoa(i) + "]"
+			}
+			issues = append(issues, validate(v.item, prefix+": ")...)
+		}
+	}
+	return issues
+}
+
+func requirements(types []string) [][]string {
+	for _, typ := range types {
+		if parent, ok := subtypes[typ]; ok {
+			typ = parent
+		}
+		if groups, ok := required[typ]; ok {
+			return groups
+		}
+	}
+	return nil
+}
+
+func hasAny(it *item, names []string) bool {
+	for _, name := range names {
+		for _, v := range it.props[name] {
+			if v.item != nil || strings.TrimSpace(v.text) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func missing(group []string) string {
+	if len(group) == 1 {
+		return fmt.Sprintf("Missing required property %q", group[0])
+	}
+	quoted := make([]string, len(group))
+	for i, name := range group {
+		quoted[i] = strconv.Quote(name)
+	}
+	return fmt.Sprintf("Missing one of %s or %s", strings.Join(quoted[:len(quoted)-1], ", "), quoted[len(quoted)-1])
+}
+
+// extractJSONLD parses the <script type="application/ld+json"> blocks of a
+// document.
+func extractJSONLD(doc *html.Node) ([]*item, []string) {
+	var items []*item
+	var errs []string
+	block := 0
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" && isJSONLD(n) {
+			block++
+			blockItems, err := parseJSONLD(rawText(n))
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("JSON-LD block %d: %v", block, err))
+			}
+			items = append(items, blockItems...)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(doc)
+	return items, errs
+}
+
+func isJSONLD(n *html.Node) bool {
+	typ, _ := htmlutil.Attr(n, "type")
+	typ, _, _ = strings.Cut(typ, ";")
+	return strings.EqualFold(strings.TrimSpace(typ), "application/ld+json")
+}
+
+// parseJSONLD returns the entities of a JSON-LD block: a single object, an
+// array of objects or an object with a @graph.
+func parseJSONLD(src string) ([]*item, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errors.New("empty block")
+	}
+	dec := json.NewDecoder(strings.NewReader(src))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, errors.New("invalid JSON: unexpected data after the top-level value")
+	}
+
+	var objects []map[string]any
+	for _, v := range list(doc) {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, errors.New("top-level values must be objects")
+		}
+		if graph, ok := obj["@graph"]; ok {
+			for _, g := range list(graph) {
+				if node, ok := g.(map[string]any); ok {
+					objects = append(objects, node)
+				}
+			}
+			continue
+		}
+		objects = append(objects, obj)
+	}
+
+	var items []*item
+	untyped := 0
+	for _, obj := range objects {
+		it := jsonItem(obj)
+		if len(it.types) == 0 {
+			untyped++
+			continue
+		}
+		items = append(items, it)
+	}
+	if untyped > 0 {
+		return items, fmt.Errorf("%d objects without @type", untyped)
+	}
+	return items, nil
+}
+
+func jsonItem(obj map[string]any) *item {
+	var types []string
+	for _, t := range list(obj["@type"]) {
+		if s, ok := t.(string); ok && s != "" {
+			types = append(types, localName(s))
+		}
+	}
+	it := newItem(types)
+	if id, ok := obj["@id"].(string); ok {
+		it.id = id
+	}
+	for key, raw := range obj {
+		if strings.HasPrefix(key, "@") {
+			continue
+		}
+		for _, v := range list(raw) {
+			if val, ok := jsonValue(v); ok {
+				it.add([]string{key}, val)
+			}
+		}
+	}
+	return it
+}
+
+func jsonValue(v any) (value, bool) {
+	switch v := v.(type) {
+	case string:
+		return value{text: v}, true
+	case json.Number:
+		return value{text: v.String()}, true
+	case bool:
+		return value{text: strconv.FormatBool(v)}, true
+	case map[string]any:
+		// Value objects such as {"@value": "2024-05-01", "@type": "Date"}.
+		if literal, ok := v["@value"]; ok {
+			return jsonValue(literal)
+		}
+		return value{item: jsonItem(v)}, true
+	}
+	return value{}, false
+}
+
+// list returns the elements of an array, or v itself.
+func list(v any) []any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	}
+	return []any{v}
+}
+
+// syntax describes an attribute-based format, microdata or RDFa.
+type syntax struct {
+	name string
+	// scope reports whether an element starts an entity and returns its types
+	// and identifier.
+	scope func(n *html.Node) (types []string, id string, ok bool)
+	// property is the attribute naming the properties an element holds.
+	property string
+	// value returns the value of a property element.
+	value func(n *html.Node) string
+}
+
+var microdata = syntax{
+	name: models.FormatMicrodata,
+	scope: func(n *html.Node) ([]string, string, bool) {
+		if _, ok := htmlutil.Attr(n, "itemscope"); !ok {
+			return nil, "", false
+		}
+		itemtype, _ := htmlutil.Attr(n, "itemtype")
+		id, _ := htmlutil.Attr(n, "itemid")
+		return typeNames(itemtype), id, true
+	},
+	property: "itemprop",
+	value:    microdataValue,
+}
+
+var rdfa = syntax{
+	name: models.FormatRDFa,
+	scope: func(n *html.Node) ([]string, string, bool) {
+		typeof, ok := htmlutil.Attr(n, "typeof")
+		if !ok {
+			return nil, "", false
+		}
+		id, ok := htmlutil.Attr(n, "resource")
+		if !ok {
+			id, _ = htmlutil.Attr(n, "about")
+		}
+		return typeNames(typeof), id, true
+	},
+	property: "property",
+	value:    rdfaValue,
+}
+
+// extract returns the top-level entities of a document. Property elements
+// outside any entity, such as Open Graph <meta property> tags, are ignored.
+func (s syntax) extract(doc *html.Node) ([]*item, []string) {
+	var items []*item
+	untyped := 0
+	var traverse func(n *html.Node, parent *item)
+	traverse = func(n *html.Node, parent *item) {
+		if n.Type == html.ElementNode {
+			prop, _ := htmlutil.Attr(n, s.property)
+			names := strings.Fields(prop)
+			if types, id, ok := s.scope(n); ok {
+				it := newItem(types)
+				it.id = id
+				switch {
+				case parent != nil && len(names) > 0:
+					parent.add(names, value{item: it})
+				case len(types) == 0:
+					untyped++
+				default:
+					items = append(items, it)
+				}
+				parent = it
+			} else if parent != nil && len(names) > 0 {
+				parent.add(names, value{text: s.value(n)})
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c, parent)
+		}
+	}
+	traverse(doc, nil)
+	if untyped > 0 {
+		return items, []string{fmt.Sprintf("%s: %d top-level items without a type", s.name, untyped)}
+	}
+	return items, nil
+}
+
+// microdataValue returns the value of an itemprop element, which depends on
+// the element as defined by the HTML standard.
+func microdataValue(n *html.Node) string {
+	var key string
+	switch n.Data {
+	case "meta":
+		key = "content"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		key = "src"
+	case "a", "area", "link":
+		key = "href"
+	case "object":
+		key = "data"
+	case "data", "meter":
+		key = "value"
+	case "time":
+		key = "datetime"
+	}
+	if v, ok := htmlutil.Attr(n, key); ok {
+		return strings.TrimSpace(v)
+	}
+	return textContent(n)
+}
+
+// rdfaValue returns the value of a property element: its content attribute,
+// the resource it links to or its text.
+func rdfaValue(n *html.Node) string {
+	for _, key := range []string{"content", "resource", "href", "src", "datetime"} {
+		if v, ok := htmlutil.Attr(n, key); ok {
+			return strings.TrimSpace(v)
+		}
+	}
+	return textContent(n)
+}
+
+// typeNames returns the schema.org names of a space-separated list of types,
+// such as "https://schema.org/Product" or "schema:Product".
+func typeNames(types string) []string {
+	var names []string
+	for _, t := range strings.Fields(types) {
+		names = append(names, localName(t))
+	}
+	return names
+}
+
+// localName strips the schema.org vocabulary from a type or property name.
+func localName(name string) string {
+	for _, prefix := range []string{"https://schema.org/", "http://schema.org/", "schema:"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return rest
+		}
+	}
+	return name
+}
+
+// rawText returns the text of a <script> element as written.
+func rawText(n *html.Node) string {
+	var buf bytes.Buffer
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			buf.WriteString(c.Data)
+		}
+	}
+	return buf.String()
+}
+
+// textContent returns the text of an element with its whitespace collapsed.
+func textContent(n *html.Node) string {
+	var buf bytes.Buffer
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			buf.WriteString(n.Data)
+			buf.WriteByte(' ')
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(n)
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
@@ -0,0 +1,168 @@
+package structureddata
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"web-analyzer/models"
+
+	"golang.org/x/net/html"
+)
+
+func extract(t *testing.T, src string) *models.StructuredData {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return Extract(doc)
+}
+
+func TestExtract_JSONLD(t *testing.T) {
+	data := extract(t, `<html><head>
+	<script type="application/ld+json">
+	{"@context": "https://schema.org", "@type": "Product", "name": "Widget",
+	 "offers": {"@type": "Offer", "price": 9.99, "priceCurrency": "EUR"}}
+	</script>
+	<script type="application/ld+json">
+	{"@context": "https://schema.org", "@graph": [
+		{"@type": "Organization", "@id": "https://example.com/#org", "name": "Acme"},
+		{"@type": "BreadcrumbList", "itemListElement": [
+			{"@type": "ListItem", "position": 1, "name": "Home", "item": "https://example.com/"},
+			{"@type": "ListItem", "name": "Widgets"}
+		]}
+	]}
+	</script>
+	</head></html>`)
+
+	if len(data.Errors) != 0 {
+		t.Errorf("unexpected errors: %v", data.Errors)
+	}
+	if len(data.Entities) != 3 {
+		t.Fatalf("expected 3 entities, got %+v", data.Entities)
+	}
+
+	product := data.Entities[0]
+	if product.Format != models.FormatJSONLD || product.Type != "Product" || len(product.Issues) != 0 {
+		t.Errorf("unexpected product: %+v", product)
+	}
+	if want := map[string][]string{"name": {"Widget"}, "offers": {"Offer"}}; !reflect.DeepEqual(product.Properties, want) {
+		t.Errorf("Properties = %v; want %v", product.Properties, want)
+	}
+
+	org := data.Entities[1]
+	if org.Type != "Organization" || org.ID != "https://example.com/#org" {
+		t.Errorf("unexpected organization: %+v", org)
+	}
+	if want := []string{`Missing required property "url"`}; !reflect.DeepEqual(org.Issues, want) {
+		t.Errorf("organization issues = %v; want %v", org.Issues, want)
+	}
+
+	breadcrumbs := data.Entities[2]
+	if want := []string{"Home", "Widgets"}; !reflect.DeepEqual(breadcrumbs.Properties["itemListElement"], want) {
+		t.Errorf("itemListElement = %v; want %v", breadcrumbs.Properties["itemListElement"], want)
+	}
+	if want := []string{`itemListElement[1]: Missing required property "position"`}; !reflect.DeepEqual(breadcrumbs.Issues, want) {
+		t.Errorf("breadcrumb issues = %v; want %v", breadcrumbs.Issues, want)
+	}
+}
+
+func TestExtract_JSONLDErrors(t *testing.T) {
+	data := extract(t, `<html><head>
+	<script type="application/ld+json">{"@type": "Article", "headline": "Hi",}</script>
+	<script type="application/ld+json"> </script>
+	<script type="application/ld+json">{"name": "Untyped"}</script>
+	<script type="application/ld+json">["text"]</script>
+	<script type="text/javascript">{not json}</script>
+	</head></html>`)
+
+	if len(data.Entities) != 0 {
+		t.Errorf("expected no entities, got %+v", data.Entities)
+	}
+	want := []string{"JSON-LD block 1: invalid JSON", "JSON-LD block 2: empty block", "JSON-LD block 3: 1 objects without @type", "JSON-LD block 4: top-level values must be objects"}
+	if len(data.Errors) != len(want) {
+		t.Fatalf("Errors = %v; want %v", data.Errors, want)
+	}
+	for i, prefix := range want {
+		if !strings.HasPrefix(data.Errors[i], prefix) {
+			t.Errorf("Errors[%d] = %q; want prefix %q", i, data.Errors[i], prefix)
+		}
+	}
+}
+
+func TestExtract_Microdata(t *testing.T) {
+	data := extract(t, `<html><body>
+	<div itemscope itemtype="https://schema.org/NewsArticle">
+		<h1 itemprop="headline">  Big   news </h1>
+		<time itemprop="datePublished" datetime="2024-05-01">May 1</time>
+		<img itemprop="image" src="/hero.png">
+		<div itemprop="publisher" itemscope itemtype="https://schema.org/Organization">
+			<span itemprop="name">Acme</span>
+		</div>
+	</div>
+	<div itemscope><span itemprop="name">Untyped</span></div>
+	</body></html>`)
+
+	if len(data.Entities) != 1 {
+		t.Fatalf("expected 1 entity, got %+v", data.Entities)
+	}
+	article := data.Entities[0]
+	if article.Format != models.FormatMicrodata || article.Type != "NewsArticle" {
+		t.Errorf("unexpected article: %+v", article)
+	}
+	want := map[string][]string{
+		"headline":      {"Big news"},
+		"datePublished": {"2024-05-01"},
+		"image":         {"/hero.png"},
+		"publisher":     {"Acme"},
+	}
+	if !reflect.DeepEqual(article.Properties, want) {
+		t.Errorf("Properties = %v; want %v", article.Properties, want)
+	}
+	wantIssues := []string{`Missing required property "author"`, `publisher: Missing required property "url"`}
+	if !reflect.DeepEqual(article.Issues, wantIssues) {
+		t.Errorf("Issues = %v; want %v", article.Issues, wantIssues)
+	}
+	if want := []string{"Microdata: 1 top-level items without a type"}; !reflect.DeepEqual(data.Errors, want) {
+		t.Errorf("Errors = %v; want %v", data.Errors, want)
+	}
+}
+
+func TestExtract_RDFa(t *testing.T) {
+	data := extract(t, `<html><head>
+		<meta property="og:title" content="Ignored">
+	</head><body vocab="https://schema.org/">
+	<div typeof="schema:Product" resource="#widget">
+		<span property="name">Widget</span>
+		<div property="aggregateRating" typeof="AggregateRating">
+			<meta property="ratingValue" content="4.5">
+		</div>
+	</div>
+	</body></html>`)
+
+	if len(data.Entities) != 1 {
+		t.Fatalf("expected 1 entity, got %+v", data.Entities)
+	}
+	product := data.Entities[0]
+	if product.Format != models.FormatRDFa || product.Type != "Product" || product.ID != "#widget" || len(product.Issues) != 0 {
+		t.Errorf("unexpected product: %+v", product)
+	}
+	if want := map[string][]string{"name": {"Widget"}, "aggregateRating": {"AggregateRating"}}; !reflect.DeepEqual(product.Properties, want) {
+		t.Errorf("Properties = %v; want %v", product.Properties, want)
+	}
+}
+
+func TestIssues(t *testing.T) {
+	if Issues(nil) != nil {
+		t.Error("expected no issues without structured data")
+	}
+	got := Issues(&models.StructuredData{
+		Entities: []models.Entity{{Type: "Product", Issues: []string{"x"}}, {Type: "Organization"}},
+		Errors:   []string{"JSON-LD block 1: empty block"},
+	})
+	want := []string{"1 structured data entities missing required properties", "1 structured data errors"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Issues = %v; want %v", got, want)
+	}
+}
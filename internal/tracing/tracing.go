@@ -0,0 +1,114 @@
+// Package tracing configures OpenTelemetry tracing. Spans are exported over
+// OTLP, or written to stdout or a file for local debugging.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies this service in exported spans.
+const ServiceName = "web-analyzer"
+
+// Exporter selects where spans are sent.
+type Exporter string
+
+const (
+	ExporterNone   Exporter = "none"
+	ExporterOTLP   Exporter = "otlp"
+	ExporterStdout Exporter = "stdout"
+	ExporterFile   Exporter = "file"
+)
+
+// Config controls how spans are exported.
+type Config struct {
+	Exporter Exporter
+	// File is the path spans are appended to when Exporter is ExporterFile.
+	File string
+}
+
+// ConfigFromEnv reads the configuration from OTEL_TRACES_EXPORTER ("otlp",
+// "stdout", "file" or "none") and TRACES_FILE. The OTLP exporter itself honours
+// the standard OTEL_EXPORTER_OTLP_* variables, such as
+// OTEL_EXPORTER_OTLP_ENDPOINT.
+func ConfigFromEnv() Config {
+	exporter := Exporter(strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")))
+	switch exporter {
+	case "":
+		exporter = ExporterNone
+	case "console":
+		exporter = ExporterStdout
+	}
+	file := os.Getenv("TRACES_FILE")
+	if file == "" {
+		file = "traces.jsonl"
+	}
+	return Config{Exporter: exporter, File: file}
+}
+
+// Setup installs a global tracer provider and W3C trace context propagation.
+// The returned function flushes pending spans and releases the exporter. With
+// ExporterNone tracing stays disabled and the shutdown function is a no-op.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var closer io.Closer
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		var f *os.File
+		f, err = os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening traces file: %w", err)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s exporter: %w", cfg.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer used for the spans of this service.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_FileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterFile, File: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, span := Tracer().Start(context.Background(), "fetch")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading traces: %v", err)
+	}
+	if !strings.Contains(string(data), `"Name":"fetch"`) {
+		t.Errorf("expected the span in the traces file, got %s", data)
+	}
+}
+
+func TestSetup_UnsupportedExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Config{Exporter: "zipkin"}); err == nil {
+		t.Error("expected an error for an unsupported exporter")
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	t.Setenv("TRACES_FILE", "")
+	cfg := ConfigFromEnv()
+	if cfg.Exporter != ExporterStdout || cfg.File != "traces.jsonl" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
@@ -0,0 +1,508 @@
+// Package webhooks notifies HTTP endpoints when analyses complete or fail.
+// Payloads are JSON, signed with HMAC-SHA256 and retried with exponential
+// backoff by a fixed pool of workers.
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/jobs"
+	"web-analyzer/models"
+)
+
+// Event is the kind of notification sent to a webhook.
+type Event string
+
+const (
+	EventCompleted Event = "analysis.completed"
+	EventFailed    Event = "analysis.failed"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of
+	// "<timestamp>.<body>", prefixed with "sha256=".
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader carries the Unix time the payload was signed at.
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+	// deliveryWorkers is the number of deliveries posted at once.
+	deliveryWorkers = 4
+	// deliveryQueueSize is the number of delivery attempts that can wait for
+	// a worker.
+	deliveryQueueSize = 1000
+	// maxDeliveries is the number of deliveries kept in the log.
+	maxDeliveries = 500
+	// callbackWebhookID identifies deliveries to a per-request callback URL.
+	callbackWebhookID = "callback"
+)
+
+var (
+	// ErrNotFound is returned when a webhook does not exist.
+	ErrNotFound = errors.New("webhook not found")
+
+	errQueueFull = errors.New("delivery queue is full")
+)
+
+// Filter restricts which completed analyses trigger a webhook. Failures are
+// always delivered to webhooks subscribed to EventFailed.
+type Filter struct {
+	// BrokenLinksOnly delivers only when the page has broken links.
+	BrokenLinksOnly bool `json:"broken_links_only,omitempty"`
+	// ScoreDropped delivers only when the score is lower than in the previous
+	// snapshot of the URL.
+	ScoreDropped bool `json:"score_dropped,omitempty"`
+}
+
+// Webhook is a notification endpoint of a workspace. It only receives events
+// for jobs of that workspace.
+type Webhook struct {
+	ID        string    `json:"id"`
+	Workspace string    `json:"workspace"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	Events    []Event   `json:"events"`
+	Filter    Filter    `json:"filter"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Payload is the JSON body posted to webhooks.
+type Payload struct {
+	Event         Event                  `json:"event"`
+	Workspace     string                 `json:"workspace"`
+	JobID         string                 `json:"job_id"`
+	URL           string                 `json:"url"`
+	Status        jobs.Status            `json:"status"`
+	Error         string                 `json:"error,omitempty"`
+	Result        *models.AnalysisResult `json:"result,omitempty"`
+	PreviousScore *int                   `json:"previous_score,omitempty"`
+	Timestamp     time.Time              `json:"timestamp"`
+}
+
+// Delivery records the attempts to deliver one payload.
+type Delivery struct {
+	ID          string    `json:"id"`
+	Workspace   string    `json:"workspace"`
+	WebhookID   string    `json:"webhook_id"`
+	Target      string    `json:"target"`
+	Event       Event     `json:"event"`
+	JobID       string    `json:"job_id"`
+	Attempts    int       `json:"attempts"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Delivered   bool      `json:"delivered"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastAttempt time.Time `json:"last_attempt,omitempty"`
+}
+
+// Dispatcher sends notifications for finished jobs.
+type Dispatcher struct {
+	client    *http.Client
+	queue     chan *attempt
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// CallbackSecret signs payloads sent to per-request callback URLs. Callbacks
+	// are not sent when it is empty.
+	CallbackSecret string
+	// MaxAttempts is the number of delivery attempts before giving up.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles on every retry.
+	Backoff time.Duration
+
+	mu         sync.RWMutex
+	path       string
+	webhooks   map[string]*Webhook
+	deliveries []*Delivery
+}
+
+// attempt is a pending delivery attempt.
+type attempt struct {
+	delivery *Delivery
+	secret   string
+	body     []byte
+	number   int
+	backoff  time.Duration
+}
+
+// NewDispatcher creates a Dispatcher and starts its workers. Webhooks are kept
+// in memory until Load sets the file they are saved to.
+func NewDispatcher() *Dispatcher {
+	d := &Dispatcher{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan *attempt, deliveryQueueSize),
+		done:        make(chan struct{}),
+		MaxAttempts: defaultMaxAttempts,
+		Backoff:     defaultBackoff,
+		webhooks:    make(map[string]*Webhook),
+	}
+	for i := 0; i < deliveryWorkers; i++ {
+		go d.worker()
+	}
+	return d
+}
+
+// Close stops the workers and pending retries. Deliveries that have not
+// succeeded yet stay undelivered in the log.
+func (d *Dispatcher) Close() {
+	d.closeOnce.Do(func() { close(d.done) })
+}
+
+// Register adds a webhook to a workspace. When secret is empty a random one is
+// generated. Events defaults to both completion and failure.
+func (d *Dispatcher) Register(workspaceID, url, secret string, events []Event, filter Filter) (Webhook, error) {
+	if secret == "" {
+		secret = newID()
+	}
+	if len(events) == 0 {
+		events = []Event{EventCompleted, EventFailed}
+	}
+	webhook := &Webhook{
+		ID:        newID(),
+		Workspace: workspaceID,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.webhooks[webhook.ID] = webhook
+	if err := d.save(); err != nil {
+		delete(d.webhooks, webhook.ID)
+		return Webhook{}, err
+	}
+	return *webhook, nil
+}
+
+// List returns the webhooks of a workspace ordered by creation time.
+func (d *Dispatcher) List(workspaceID string) []Webhook {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	list := make([]Webhook, 0, len(d.webhooks))
+	for _, webhook := range d.webhooks {
+		if webhook.Workspace == workspaceID {
+			list = append(list, *webhook)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list
+}
+
+// Delete removes a webhook of a workspace.
+func (d *Dispatcher) Delete(workspaceID, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	webhook, ok := d.webhooks[id]
+	if !ok || webhook.Workspace != workspaceID {
+		return ErrNotFound
+	}
+	delete(d.webhooks, id)
+	if err := d.save(); err != nil {
+		d.webhooks[id] = webhook
+		return err
+	}
+	return nil
+}
+
+// Deliveries returns the delivery log of a workspace, most recent first.
+func (d *Dispatcher) Deliveries(workspaceID string) []Delivery {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	list := make([]Delivery, 0, len(d.deliveries))
+	for i := len(d.deliveries) - 1; i >= 0; i-- {
+		if d.deliveries[i].Workspace == workspaceID {
+			list = append(list, *d.deliveries[i])
+		}
+	}
+	return list
+}
+
+// Notify sends the outcome of job to its callback URL and to every matching
+// webhook of its workspace. Deliveries are queued for the workers; when the
+// queue is full they are logged as failed.
+func (d *Dispatcher) Notify(job jobs.Job) {
+	payload := buildPayload(job)
+
+	if job.CallbackURL != "" && d.CallbackSecret != "" {
+		d.deliver(job.Workspace, callbackWebhookID, job.CallbackURL, d.CallbackSecret, payload)
+	}
+
+	d.mu.RLock()
+	var targets []Webhook
+	for _, webhook := range d.webhooks {
+		if webhook.Workspace == job.Workspace && webhook.matches(payload) {
+			targets = append(targets, *webhook)
+		}
+	}
+	d.mu.RUnlock()
+
+	for _, webhook := range targets {
+		d.deliver(webhook.Workspace, webhook.ID, webhook.URL, webhook.Secret, payload)
+	}
+}
+
+// buildPayload describes the outcome of job with the result and previous score
+// recorded on the job itself, so concurrent jobs for the same URL do not report
+// each other's results.
+func buildPayload(job jobs.Job) Payload {
+	payload := Payload{
+		Event:     EventCompleted,
+		Workspace: job.Workspace,
+		JobID:     job.ID,
+		URL:       job.URL,
+		Status:    job.Status,
+		Error:     job.Error,
+		Timestamp: time.Now(),
+	}
+	if job.Status == jobs.StatusFailed {
+		payload.Event = EventFailed
+	}
+	if payload.Event == EventCompleted {
+		payload.Result = job.Result
+		payload.PreviousScore = job.PreviousScore
+	}
+	return payload
+}
+
+func (w *Webhook) matches(p Payload) bool {
+	subscribed := false
+	for _, event := range w.Events {
+		if event == p.Event {
+			subscribed = true
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if p.Event != EventCompleted || p.Result == nil {
+		return true
+	}
+	if w.Filter.BrokenLinksOnly && p.Result.BrokenLinks == 0 {
+		return false
+	}
+	if w.Filter.ScoreDropped && (p.PreviousScore == nil || p.Result.Score >= *p.PreviousScore) {
+		return false
+	}
+	return true
+}
+
+func (d *Dispatcher) deliver(workspaceID, webhookID, target, secret string, payload Payload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("Failed to encode webhook payload", "error", err)
+		return
+	}
+
+	delivery := &Delivery{
+		ID:        newID(),
+		Workspace: workspaceID,
+		WebhookID: webhookID,
+		Target:    target,
+		Event:     payload.Event,
+		JobID:     payload.JobID,
+		CreatedAt: time.Now(),
+	}
+	d.record(delivery)
+
+	select {
+	case d.queue <- &attempt{delivery: delivery, secret: secret, body: body, number: 1, backoff: d.Backoff}:
+	default:
+		slog.Warn("Webhook delivery dropped", "webhook_id", webhookID, "target", target, "error", errQueueFull)
+		d.update(func() { delivery.Error = errQueueFull.Error() })
+	}
+}
+
+func (d *Dispatcher) worker() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case a := <-d.queue:
+			d.attempt(a)
+		}
+	}
+}
+
+// attempt posts a delivery and, when it fails, schedules the next attempt
+// after the backoff. Retries are dropped once the dispatcher is closed.
+func (d *Dispatcher) attempt(a *attempt) {
+	delivery := a.delivery
+	status, err := d.post(delivery.Target, a.secret, delivery.ID, delivery.Event, a.body)
+	d.update(func() {
+		delivery.Attempts = a.number
+		delivery.LastAttempt = time.Now()
+		delivery.StatusCode = status
+		delivery.Error = ""
+		if err != nil {
+			delivery.Error = err.Error()
+		} else {
+			delivery.Delivered = true
+		}
+	})
+	if err == nil {
+		slog.Info("Webhook delivered", "webhook_id", delivery.WebhookID, "target", delivery.Target, "job_id", delivery.JobID, "attempts", a.number)
+		return
+	}
+	slog.Warn("Webhook delivery failed", "webhook_id", delivery.WebhookID, "target", delivery.Target, "attempt", a.number, "error", err)
+	if a.number >= d.MaxAttempts {
+		return
+	}
+	next := &attempt{delivery: delivery, secret: a.secret, body: a.body, number: a.number + 1, backoff: a.backoff * 2}
+	time.AfterFunc(a.backoff, func() {
+		select {
+		case <-d.done:
+		case d.queue <- next:
+		}
+	})
+}
+
+func (d *Dispatcher) post(target, secret, deliveryID string, event Event, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event))
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(TimestampHeader, timestamp)
+	if secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(secret, timestamp, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with
+// secret. Receivers recompute it to verify a payload.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (d *Dispatcher) record(delivery *Delivery) {
+	d.update(func() {
+		d.deliveries = append(d.deliveries, delivery)
+		if len(d.deliveries) > maxDeliveries {
+			d.deliveries = d.deliveries[len(d.deliveries)-maxDeliveries:]
+		}
+	})
+}
+
+// update applies fn to the delivery log and saves it.
+func (d *Dispatcher) update(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn()
+	if err := d.save(); err != nil {
+		slog.Error("Failed to save webhook deliveries", "error", err)
+	}
+}
+
+// stored is the file format of the webhooks and the delivery log. Unlike the
+// API, it includes the secrets of the webhooks.
+type stored struct {
+	Webhooks   []storedWebhook `json:"webhooks"`
+	Deliveries []*Delivery     `json:"deliveries"`
+}
+
+type storedWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+// Load reads the webhooks and delivery log saved at path, and saves later
+// changes there. A missing file is not an error.
+func (d *Dispatcher) Load(path string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading webhooks: %w", err)
+	}
+	var file stored
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("decoding webhooks: %w", err)
+	}
+	for _, w := range file.Webhooks {
+		if w.Webhook == nil {
+			continue
+		}
+		w.Webhook.Secret = w.Secret
+		d.webhooks[w.ID] = w.Webhook
+	}
+	d.deliveries = file.Deliveries
+	return nil
+}
+
+// save writes the webhooks and delivery log to disk atomically. The caller
+// must hold d.mu.
+func (d *Dispatcher) save() error {
+	if d.path == "" {
+		return nil
+	}
+	file := stored{Webhooks: make([]storedWebhook, 0, len(d.webhooks)), Deliveries: d.deliveries}
+	for _, webhook := range d.webhooks {
+		file.Webhooks = append(file.Webhooks, storedWebhook{Webhook: webhook, Secret: webhook.Secret})
+	}
+	sort.Slice(file.Webhooks, func(i, j int) bool { return file.Webhooks[i].CreatedAt.Before(file.Webhooks[j].CreatedAt) })
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o700); err != nil {
+		return fmt.Errorf("creating webhooks directory: %w", err)
+	}
+	tmp := d.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing webhooks: %w", err)
+	}
+	if err := os.Rename(tmp, d.path); err != nil {
+		return fmt.Errorf("writing webhooks: %w", err)
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
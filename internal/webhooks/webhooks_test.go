@@ -0,0 +1,267 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"web-analyzer/internal/jobs"
+	"web-analyzer/internal/workspace"
+	"web-analyzer/models"
+)
+
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	failures int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcher_SignsAndRetries(t *testing.T) {
+	rcv := &receiver{failures: 2}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	d := NewDispatcher()
+	defer d.Close()
+	d.Backoff = time.Millisecond
+	webhook, err := d.Register(workspace.Default, server.URL, "s3cret", nil, Filter{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	result := &models.AnalysisResult{URL: "https://example.com", Status: "Completed", Score: 90}
+	d.Notify(jobs.Job{ID: "job-1", Workspace: workspace.Default, URL: "https://example.com", Status: jobs.StatusCompleted, Result: result})
+	waitFor(t, func() bool { return rcv.count() == 3 })
+	waitFor(t, func() bool {
+		return len(d.Deliveries(workspace.Default)) == 1 && d.Deliveries(workspace.Default)[0].Delivered
+	})
+
+	delivery := d.Deliveries(workspace.Default)[0]
+	if delivery.Attempts != 3 || delivery.WebhookID != webhook.ID {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+
+	rcv.mu.Lock()
+	req, body := rcv.requests[2], rcv.bodies[2]
+	rcv.mu.Unlock()
+	want := "sha256=" + Sign("s3cret", req.Header.Get(TimestampHeader), body)
+	if got := req.Header.Get(SignatureHeader); got != want {
+		t.Errorf("signature = %q; want %q", got, want)
+	}
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.Event != EventCompleted || payload.Result == nil || payload.Result.Score != 90 {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestWebhook_Filters(t *testing.T) {
+	previous := 80
+	completed := func(brokenLinks, score int, prev *int) Payload {
+		return Payload{
+			Event:         EventCompleted,
+			Result:        &models.AnalysisResult{BrokenLinks: brokenLinks, Score: score},
+			PreviousScore: prev,
+		}
+	}
+
+	brokenOnly := &Webhook{Events: []Event{EventCompleted, EventFailed}, Filter: Filter{BrokenLinksOnly: true}}
+	if brokenOnly.matches(completed(0, 100, nil)) {
+		t.Error("expected page without broken links to be filtered")
+	}
+	if !brokenOnly.matches(completed(2, 90, nil)) {
+		t.Error("expected page with broken links to match")
+	}
+	if !brokenOnly.matches(Payload{Event: EventFailed}) {
+		t.Error("expected failures to bypass filters")
+	}
+
+	scoreDropped := &Webhook{Events: []Event{EventCompleted}, Filter: Filter{ScoreDropped: true}}
+	if scoreDropped.matches(completed(0, 90, nil)) {
+		t.Error("expected first snapshot not to count as a drop")
+	}
+	if scoreDropped.matches(completed(0, 85, &previous)) {
+		t.Error("expected higher score to be filtered")
+	}
+	if !scoreDropped.matches(completed(0, 70, &previous)) {
+		t.Error("expected lower score to match")
+	}
+	if scoreDropped.matches(Payload{Event: EventFailed}) {
+		t.Error("expected unsubscribed event to be filtered")
+	}
+}
+
+func TestDispatcher_Callback(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	d := NewDispatcher()
+	defer d.Close()
+	d.CallbackSecret = "callback-secret"
+
+	d.Notify(jobs.Job{ID: "job-2", URL: "https://example.com", Status: jobs.StatusFailed, Error: "fetch failed", CallbackURL: server.URL})
+	waitFor(t, func() bool { return rcv.count() == 1 })
+
+	rcv.mu.Lock()
+	req := rcv.requests[0]
+	rcv.mu.Unlock()
+	if req.Header.Get(EventHeader) != string(EventFailed) {
+		t.Errorf("expected failed event, got %q", req.Header.Get(EventHeader))
+	}
+	if req.Header.Get(SignatureHeader) == "" {
+		t.Error("expected callback to be signed")
+	}
+}
+
+func TestDispatcher_ScopesWebhooksToWorkspace(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	d := NewDispatcher()
+	defer d.Close()
+	if _, err := d.Register("team-a", server.URL, "s3cret", nil, Filter{}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if got := d.List("team-b"); len(got) != 0 {
+		t.Fatalf("expected no webhooks in team-b, got %d", len(got))
+	}
+
+	d.Notify(jobs.Job{ID: "job-1", Workspace: "team-b", URL: "https://example.com", Status: jobs.StatusFailed})
+	d.Notify(jobs.Job{ID: "job-2", Workspace: "team-a", URL: "https://example.com", Status: jobs.StatusFailed})
+	waitFor(t, func() bool { return len(d.Deliveries("team-a")) == 1 && d.Deliveries("team-a")[0].Delivered })
+	if rcv.count() != 1 {
+		t.Errorf("expected only the team-a job to be delivered, got %d requests", rcv.count())
+	}
+	if got := d.Deliveries("team-b"); len(got) != 0 {
+		t.Errorf("expected no deliveries in team-b, got %d", len(got))
+	}
+}
+
+func TestBuildPayload_UsesJobResult(t *testing.T) {
+	previous := 80
+	job := jobs.Job{
+		ID:            "job-1",
+		URL:           "https://example.com",
+		Status:        jobs.StatusCompleted,
+		Result:        &models.AnalysisResult{URL: "https://example.com", Score: 70},
+		PreviousScore: &previous,
+	}
+	payload := buildPayload(job)
+	if payload.Result == nil || payload.Result.Score != 70 {
+		t.Errorf("expected the job's own result, got %+v", payload.Result)
+	}
+	if payload.PreviousScore == nil || *payload.PreviousScore != 80 {
+		t.Errorf("expected previous score 80, got %v", payload.PreviousScore)
+	}
+
+	job.Status, job.Error = jobs.StatusFailed, "fetch failed"
+	if payload := buildPayload(job); payload.Result != nil || payload.PreviousScore != nil {
+		t.Errorf("expected failed payload without result, got %+v", payload)
+	}
+}
+
+func TestDispatcher_PersistsWebhooksAndDeliveries(t *testing.T) {
+	rcv := &receiver{}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+
+	d := NewDispatcher()
+	if err := d.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	webhook, err := d.Register("team-a", server.URL, "s3cret", nil, Filter{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	d.Notify(jobs.Job{ID: "job-1", Workspace: "team-a", URL: "https://example.com", Status: jobs.StatusFailed})
+	waitFor(t, func() bool { return len(d.Deliveries("team-a")) == 1 && d.Deliveries("team-a")[0].Delivered })
+	d.Close()
+
+	reloaded := NewDispatcher()
+	defer reloaded.Close()
+	if err := reloaded.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	list := reloaded.List("team-a")
+	if len(list) != 1 || list[0].ID != webhook.ID || list[0].Secret != "s3cret" {
+		t.Fatalf("expected webhook to survive a restart, got %+v", list)
+	}
+	deliveries := reloaded.Deliveries("team-a")
+	if len(deliveries) != 1 || !deliveries[0].Delivered || deliveries[0].JobID != "job-1" {
+		t.Errorf("expected delivery log to survive a restart, got %+v", deliveries)
+	}
+
+	if err := reloaded.Delete("team-a", webhook.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	again := NewDispatcher()
+	defer again.Close()
+	if err := again.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := again.List("team-a"); len(got) != 0 {
+		t.Errorf("expected deleted webhook to stay deleted, got %+v", got)
+	}
+}
+
+func TestDispatcher_CloseStopsRetries(t *testing.T) {
+	rcv := &receiver{failures: 100}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	d := NewDispatcher()
+	d.Backoff = 20 * time.Millisecond
+	if _, err := d.Register(workspace.Default, server.URL, "s3cret", nil, Filter{}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	d.Notify(jobs.Job{ID: "job-1", Workspace: workspace.Default, URL: "https://example.com", Status: jobs.StatusFailed})
+	waitFor(t, func() bool { return rcv.count() == 1 })
+	d.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := rcv.count(); got != 1 {
+		t.Errorf("expected no retries after Close, got %d attempts", got)
+	}
+}
@@ -0,0 +1,302 @@
+// Package workspace isolates the data of the teams sharing an instance. Every
+// workspace has its own analyzer service, and therefore its own submitted URLs,
+// results and history, plus usage quotas.
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"web-analyzer/internal/analyzer"
+)
+
+// Default is the workspace of unauthenticated requests and of keys issued
+// without a workspace.
+const Default = "default"
+
+var (
+	// ErrQuotaExceeded is returned when a workspace has used up its quota.
+	ErrQuotaExceeded = errors.New("workspace quota exceeded")
+	// ErrInvalidID is returned for malformed workspace IDs.
+	ErrInvalidID = errors.New("invalid workspace ID")
+)
+
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ValidateID checks that id is a lowercase slug of at most 63 characters.
+func ValidateID(id string) error {
+	if !validID.MatchString(id) {
+		return fmt.Errorf("%w %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx scoped to the workspace id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the workspace ctx is scoped to, or Default.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Default
+}
+
+// Quota limits the usage of a workspace. Zero values mean unlimited.
+type Quota struct {
+	// AnalysesPerDay is the number of analyses that can be queued per UTC day.
+	AnalysesPerDay int `json:"analyses_per_day"`
+	// CrawlPages is the maximum number of pages a single crawl may analyse.
+	CrawlPages int `json:"crawl_pages"`
+}
+
+// Usage is the consumption of a workspace on the current UTC day.
+type Usage struct {
+	Day      string `json:"day"`
+	Analyses int    `json:"analyses"`
+}
+
+// Info describes a workspace, its quota and its usage.
+type Info struct {
+	ID    string `json:"id"`
+	Quota Quota  `json:"quota"`
+	Usage Usage  `json:"usage"`
+}
+
+// DefaultMaxCrawls is the default of Registry.MaxCrawls.
+const DefaultMaxCrawls = 2
+
+// Registry hands out the analyzer service of each workspace, creating it on
+// first use, and enforces quotas.
+type Registry struct {
+	newService   func(id string) analyzer.AnalyzerService
+	defaultQuota Quota
+	now          func() time.Time
+
+	// MaxCrawls is the number of crawls a workspace may run at once.
+	MaxCrawls int
+
+	mu       sync.Mutex
+	path     string
+	services map[string]analyzer.AnalyzerService
+	quotas   map[string]Quota
+	usage    map[string]*Usage
+	crawls   map[string]int
+}
+
+// NewRegistry creates a Registry that builds the service of a workspace with
+// newService. Workspaces without their own quota get defaultQuota.
+func NewRegistry(newService func(id string) analyzer.AnalyzerService, defaultQuota Quota) *Registry {
+	return &Registry{
+		newService:   newService,
+		defaultQuota: defaultQuota,
+		now:          time.Now,
+		MaxCrawls:    DefaultMaxCrawls,
+		services:     make(map[string]analyzer.AnalyzerService),
+		quotas:       make(map[string]Quota),
+		usage:        make(map[string]*Usage),
+		crawls:       make(map[string]int),
+	}
+}
+
+// Static returns a Registry whose workspaces all share service, without quotas.
+// It suits single-tenant setups and tests.
+func Static(service analyzer.AnalyzerService) *Registry {
+	return NewRegistry(func(string) analyzer.AnalyzerService { return service }, Quota{})
+}
+
+// Service returns the analyzer service of workspace id.
+func (r *Registry) Service(id string) analyzer.AnalyzerService {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	service, ok := r.services[id]
+	if !ok {
+		service = r.newService(id)
+		r.services[id] = service
+	}
+	return service
+}
+
+// SetQuota overrides the quota of workspace id and saves the overrides when
+// LoadQuotas has set a file.
+func (r *Registry) SetQuota(id string, quota Quota) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous, existed := r.quotas[id]
+	r.quotas[id] = quota
+	if err := r.saveQuotas(); err != nil {
+		if existed {
+			r.quotas[id] = previous
+		} else {
+			delete(r.quotas, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// LoadQuotas reads the quota overrides saved at path, and saves later changes
+// there. A missing file is not an error.
+func (r *Registry) LoadQuotas(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading quotas: %w", err)
+	}
+	quotas := make(map[string]Quota)
+	if err := json.Unmarshal(data, &quotas); err != nil {
+		return fmt.Errorf("decoding quotas: %w", err)
+	}
+	r.quotas = quotas
+	return nil
+}
+
+// saveQuotas writes the quota overrides to disk atomically. The caller must
+// hold r.mu.
+func (r *Registry) saveQuotas() error {
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(r.quotas, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return fmt.Errorf("creating quotas directory: %w", err)
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing quotas: %w", err)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("writing quotas: %w", err)
+	}
+	return nil
+}
+
+// Quota returns the quota of workspace id.
+func (r *Registry) Quota(id string) Quota {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.quota(id)
+}
+
+func (r *Registry) quota(id string) Quota {
+	if quota, ok := r.quotas[id]; ok {
+		return quota
+	}
+	return r.defaultQuota
+}
+
+// ReserveAnalysis counts one analysis against the daily quota of workspace id,
+// returning ErrQuotaExceeded when none is left.
+func (r *Registry) ReserveAnalysis(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	usage := r.currentUsage(id)
+	if limit := r.quota(id).AnalysesPerDay; limit > 0 && usage.Analyses >= limit {
+		return fmt.Errorf("%w: %d analyses per day", ErrQuotaExceeded, limit)
+	}
+	usage.Analyses++
+	return nil
+}
+
+// ReleaseAnalysis gives back an analysis reserved with ReserveAnalysis that was
+// not run after all.
+func (r *Registry) ReleaseAnalysis(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if usage := r.currentUsage(id); usage.Analyses > 0 {
+		usage.Analyses--
+	}
+}
+
+// CheckCrawl returns ErrQuotaExceeded when a crawl of pages pages exceeds the
+// crawl quota of workspace id.
+func (r *Registry) CheckCrawl(id string, pages int) error {
+	if limit := r.Quota(id).CrawlPages; limit > 0 && pages > limit {
+		return fmt.Errorf("%w: at most %d pages per crawl", ErrQuotaExceeded, limit)
+	}
+	return nil
+}
+
+// StartCrawl reserves one of the concurrent crawls of workspace id, returning
+// ErrQuotaExceeded when MaxCrawls crawls are already running. The returned
+// function ends the crawl.
+func (r *Registry) StartCrawl(id string) (func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.MaxCrawls > 0 && r.crawls[id] >= r.MaxCrawls {
+		return nil, fmt.Errorf("%w: at most %d concurrent crawls", ErrQuotaExceeded, r.MaxCrawls)
+	}
+	r.crawls[id]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.crawls[id]--; r.crawls[id] <= 0 {
+				delete(r.crawls, id)
+			}
+		})
+	}, nil
+}
+
+// Info returns the quota and current usage of workspace id.
+func (r *Registry) Info(id string) Info {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Info{ID: id, Quota: r.quota(id), Usage: *r.currentUsage(id)}
+}
+
+// List returns every workspace that has been used or given a quota, ordered by
+// ID.
+func (r *Registry) List() []Info {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := make(map[string]bool)
+	for id := range r.services {
+		ids[id] = true
+	}
+	for id := range r.quotas {
+		ids[id] = true
+	}
+	for id := range r.usage {
+		ids[id] = true
+	}
+	list := make([]Info, 0, len(ids))
+	for id := range ids {
+		list = append(list, Info{ID: id, Quota: r.quota(id), Usage: *r.currentUsage(id)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// currentUsage returns the usage of workspace id for today, resetting it when
+// the day has changed. The caller must hold r.mu.
+func (r *Registry) currentUsage(id string) *Usage {
+	day := r.now().UTC().Format(time.DateOnly)
+	usage, ok := r.usage[id]
+	if !ok || usage.Day != day {
+		usage = &Usage{Day: day}
+		r.usage[id] = usage
+	}
+	return usage
+}
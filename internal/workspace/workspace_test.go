@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"web-analyzer/internal/analyzer"
+)
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != Default {
+		t.Errorf("expected %q, got %q", Default, got)
+	}
+	if got := FromContext(WithID(context.Background(), "team-a")); got != "team-a" {
+		t.Errorf("expected team-a, got %q", got)
+	}
+}
+
+func TestValidateID(t *testing.T) {
+	for _, id := range []string{"default", "team-a", "a1_b2"} {
+		if err := ValidateID(id); err != nil {
+			t.Errorf("expected %q to be valid: %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "Team", "-a", "a/b", "a b"} {
+		if err := ValidateID(id); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("expected %q to be invalid, got %v", id, err)
+		}
+	}
+}
+
+func TestRegistry_ServicePerWorkspace(t *testing.T) {
+	created := 0
+	r := NewRegistry(func(string) analyzer.AnalyzerService {
+		created++
+		return nil
+	}, Quota{})
+	r.Service("team-a")
+	r.Service("team-a")
+	r.Service("team-b")
+	if created != 2 {
+		t.Errorf("expected one service per workspace, got %d", created)
+	}
+}
+
+func TestRegistry_AnalysesPerDay(t *testing.T) {
+	now := time.Date(2025, 3, 1, 23, 0, 0, 0, time.UTC)
+	r := NewRegistry(func(string) analyzer.AnalyzerService { return nil }, Quota{AnalysesPerDay: 2})
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := r.ReserveAnalysis("team-a"); err != nil {
+			t.Fatalf("reservation %d: %v", i, err)
+		}
+	}
+	if err := r.ReserveAnalysis("team-a"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if err := r.ReserveAnalysis("team-b"); err != nil {
+		t.Errorf("expected quotas to be per workspace, got %v", err)
+	}
+
+	r.ReleaseAnalysis("team-a")
+	if err := r.ReserveAnalysis("team-a"); err != nil {
+		t.Errorf("expected a released analysis to be available again, got %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if info := r.Info("team-a"); info.Usage.Analyses != 0 || info.Usage.Day != "2025-03-02" {
+		t.Errorf("expected usage to reset on a new day, got %+v", info.Usage)
+	}
+
+	if err := r.SetQuota("team-a", Quota{}); err != nil {
+		t.Fatalf("SetQuota: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := r.ReserveAnalysis("team-a"); err != nil {
+			t.Fatalf("expected unlimited quota, got %v", err)
+		}
+	}
+}
+
+func TestRegistry_PersistsQuotas(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quotas.json")
+	newService := func(string) analyzer.AnalyzerService { return nil }
+
+	r := NewRegistry(newService, Quota{AnalysesPerDay: 10})
+	if err := r.LoadQuotas(path); err != nil {
+		t.Fatalf("LoadQuotas: %v", err)
+	}
+	if err := r.SetQuota("team-a", Quota{AnalysesPerDay: 500, CrawlPages: 200}); err != nil {
+		t.Fatalf("SetQuota: %v", err)
+	}
+
+	reloaded := NewRegistry(newService, Quota{AnalysesPerDay: 10})
+	if err := reloaded.LoadQuotas(path); err != nil {
+		t.Fatalf("LoadQuotas: %v", err)
+	}
+	if got := reloaded.Quota("team-a"); got != (Quota{AnalysesPerDay: 500, CrawlPages: 200}) {
+		t.Errorf("expected override to survive a restart, got %+v", got)
+	}
+	if got := reloaded.Quota("team-b"); got != (Quota{AnalysesPerDay: 10}) {
+		t.Errorf("expected default quota for other workspaces, got %+v", got)
+	}
+}
+
+func TestRegistry_CheckCrawl(t *testing.T) {
+	r := NewRegistry(func(string) analyzer.AnalyzerService { return nil }, Quota{CrawlPages: 10})
+	if err := r.CheckCrawl("team-a", 10); err != nil {
+		t.Errorf("expected 10 pages to be allowed, got %v", err)
+	}
+	if err := r.CheckCrawl("team-a", 11); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+}
+
+func TestRegistry_StartCrawl(t *testing.T) {
+	r := NewRegistry(func(string) analyzer.AnalyzerService { return nil }, Quota{})
+	r.MaxCrawls = 1
+
+	done, err := r.StartCrawl("team-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := r.StartCrawl("team-a"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+	if _, err := r.StartCrawl("team-b"); err != nil {
+		t.Errorf("expected crawls to be limited per workspace, got %v", err)
+	}
+
+	done()
+	done()
+	if _, err := r.StartCrawl("team-a"); err != nil {
+		t.Errorf("expected the finished crawl to be released, got %v", err)
+	}
+	if _, err := r.StartCrawl("team-a"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("expected a repeated release to be ignored, got %v", err)
+	}
+}
@@ -1,13 +1,170 @@
 package models
 
+import "time"
+
 type AnalysisResult struct {
-	Status        string         `json:"Status"`
-	HTMLVersion   string         `json:"HTML Version"`
-	Title         string         `json:"Title"`
-	Headings      map[string]int `json:"Headings"`
-	InternalLinks int            `json:"Internal Links"`
-	ExternalLinks int            `json:"External Links"`
-	BrokenLinks   int            `json:"Broken Links"`
-	LoginForm     string         `json:"Login Form"`
-	Message       string         `json:"Message,omitempty"`
+	URL               string          `json:"URL,omitempty"`
+	Status            string          `json:"Status"`
+	HTMLVersion       string          `json:"HTML Version"`
+	Title             string          `json:"Title"`
+	Headings          map[string]int  `json:"Headings"`
+	InternalLinks     int             `json:"Internal Links"`
+	ExternalLinks     int             `json:"External Links"`
+	BrokenLinks       int             `json:"Broken Links"`
+	BrokenLinkURLs    []string        `json:"Broken Link URLs,omitempty"`
+	Links             []string        `json:"Links,omitempty"`
+	LoginForm         string          `json:"Login Form"`
+	Score             int             `json:"Score"`
+	FinalURL          string          `json:"Final URL,omitempty"`
+	RedirectChain     []RedirectHop   `json:"Redirect Chain,omitempty"`
+	RedirectLoop      bool            `json:"Redirect Loop,omitempty"`
+	HTTPSDowngrade    bool            `json:"HTTPS Downgrade,omitempty"`
+	LongRedirectChain bool            `json:"Long Redirect Chain,omitempty"`
+	Technologies      []Technology    `json:"Technologies,omitempty"`
+	Security          *Security       `json:"Security,omitempty"`
+	Resources         *Resources      `json:"Resources,omitempty"`
+	Performance       *Performance    `json:"Performance,omitempty"`
+	StructuredData    *StructuredData `json:"Structured Data,omitempty"`
+	Message           string          `json:"Message,omitempty"`
+	AnalyzedAt        time.Time       `json:"Analyzed At"`
+}
+
+// RedirectHop is a single response received while fetching a page.
+type RedirectHop struct {
+	URL        string `json:"URL"`
+	StatusCode int    `json:"Status Code"`
+	LatencyMs  int64  `json:"Latency (ms)"`
+}
+
+// Technology is a product, framework or service a page is built with.
+type Technology struct {
+	Name     string `json:"Name"`
+	Category string `json:"Category,omitempty"`
+	Version  string `json:"Version,omitempty"`
+}
+
+// Header statuses of a SecurityHeader.
+const (
+	HeaderGood    = "Good"
+	HeaderWeak    = "Weak"
+	HeaderMissing = "Missing"
+)
+
+// Security audits the response headers, cookies, subresources and connection
+// of a fetched page.
+type Security struct {
+	Headers []SecurityHeader `json:"Headers"`
+	Cookies []CookieAudit    `json:"Cookies,omitempty"`
+	// MixedContent lists the http:// subresources of an https page.
+	MixedContent []string `json:"Mixed Content,omitempty"`
+	// TLS is nil for pages not served over HTTPS.
+	TLS *TLSInfo `json:"TLS,omitempty"`
+}
+
+// SecurityHeader rates a security response header.
+type SecurityHeader struct {
+	Name   string   `json:"Name"`
+	Value  string   `json:"Value,omitempty"`
+	Status string   `json:"Status"`
+	Issues []string `json:"Issues,omitempty"`
+}
+
+// CookieAudit lists the flags of a cookie set by the page.
+type CookieAudit struct {
+	Name     string   `json:"Name"`
+	Secure   bool     `json:"Secure"`
+	HttpOnly bool     `json:"HttpOnly"`
+	SameSite string   `json:"SameSite,omitempty"`
+	Issues   []string `json:"Issues,omitempty"`
+}
+
+// TLSInfo describes the TLS connection a page was fetched over.
+type TLSInfo struct {
+	Version            string    `json:"Version"`
+	CipherSuite        string    `json:"Cipher Suite"`
+	CertificateSubject string    `json:"Certificate Subject,omitempty"`
+	CertificateIssuer  string    `json:"Certificate Issuer,omitempty"`
+	CertificateExpiry  time.Time `json:"Certificate Expiry"`
+	DaysUntilExpiry    int       `json:"Days Until Expiry"`
+	Issues             []string  `json:"Issues,omitempty"`
+}
+
+// Types of a Resource.
+const (
+	ResourceScript     = "Script"
+	ResourceStylesheet = "Stylesheet"
+	ResourceImage      = "Image"
+	ResourceIframe     = "Iframe"
+	ResourceMedia      = "Media"
+	// ResourceCSS is a url() of an inline style.
+	ResourceCSS = "CSS"
+)
+
+// Resource is a subresource referenced by a page.
+type Resource struct {
+	URL  string `json:"URL"`
+	Type string `json:"Type"`
+	// ThirdParty is set for resources outside the page's registrable domain.
+	ThirdParty bool `json:"Third Party"`
+	Broken     bool `json:"Broken,omitempty"`
+}
+
+// Resources inventories the subresources and inline code of a page.
+type Resources struct {
+	Items           []Resource `json:"Items"`
+	FirstParty      int        `json:"First Party"`
+	ThirdParty      int        `json:"Third Party"`
+	Broken          int        `json:"Broken"`
+	InlineScripts   int        `json:"Inline Scripts"`
+	InlineStyles    int        `json:"Inline Styles"`
+	StyleAttributes int        `json:"Style Attributes"`
+}
+
+// Performance estimates the weight of a page and lists what slows its loading.
+// Sizes count the bytes transferred, compressed if the server compressed them.
+type Performance struct {
+	DocumentBytes int64 `json:"Document Bytes"`
+	// TotalBytes and BytesByType only include the document unless the
+	// subresources were fetched.
+	TotalBytes  int64            `json:"Total Bytes"`
+	BytesByType map[string]int64 `json:"Bytes By Type"`
+	// Requests is the number of distinct URLs the page loads, itself included.
+	Requests int  `json:"Requests"`
+	Fetched  bool `json:"Fetched"`
+	// Truncated is set when a size or request cap stopped the fetching.
+	Truncated                bool     `json:"Truncated,omitempty"`
+	RenderBlocking           []string `json:"Render Blocking,omitempty"`
+	ImagesWithoutDimensions  []string `json:"Images Without Dimensions,omitempty"`
+	ImagesWithoutLazyLoading []string `json:"Images Without Lazy Loading,omitempty"`
+	Uncompressed             []string `json:"Uncompressed,omitempty"`
+	Uncached                 []string `json:"Uncached,omitempty"`
+}
+
+// Formats of an Entity.
+const (
+	FormatJSONLD    = "JSON-LD"
+	FormatMicrodata = "Microdata"
+	FormatRDFa      = "RDFa"
+)
+
+// StructuredData lists the schema.org entities a page describes.
+type StructuredData struct {
+	Entities []Entity `json:"Entities"`
+	// Errors lists the blocks and items that could not be parsed, e.g. invalid
+	// JSON-LD.
+	Errors []string `json:"Errors,omitempty"`
+}
+
+// Entity is a schema.org entity described by a page, such as a Product.
+type Entity struct {
+	Format string `json:"Format"`
+	// Type is the first type of the entity without the schema.org prefix.
+	Type string `json:"Type"`
+	ID   string `json:"ID,omitempty"`
+	// Properties maps property names to their values. Nested entities are
+	// represented by their name, identifier or type.
+	Properties map[string][]string `json:"Properties"`
+	// Issues lists the required properties missing from the entity and the
+	// entities nested in it.
+	Issues []string `json:"Issues,omitempty"`
 }
@@ -0,0 +1,26 @@
+package models
+
+// Snapshot is a completed analysis of a URL kept in its history.
+type Snapshot struct {
+	Version int            `json:"Version"`
+	Result  AnalysisResult `json:"Result"`
+}
+
+// FieldChange describes a field whose value differs between two snapshots.
+type FieldChange struct {
+	Field string `json:"Field"`
+	Old   string `json:"Old"`
+	New   string `json:"New"`
+}
+
+// SnapshotDiff lists what changed between two snapshots of the same URL.
+type SnapshotDiff struct {
+	URL              string        `json:"URL"`
+	FromVersion      int           `json:"From Version"`
+	ToVersion        int           `json:"To Version"`
+	Changes          []FieldChange `json:"Changes"`
+	LinksAdded       []string      `json:"Links Added"`
+	LinksRemoved     []string      `json:"Links Removed"`
+	NewlyBrokenLinks []string      `json:"Newly Broken Links"`
+	FixedLinks       []string      `json:"Fixed Links"`
+}
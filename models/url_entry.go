@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// URLEntry summarises the submissions of a URL and the outcome of its latest
+// analysis.
+type URLEntry struct {
+	URL              string     `json:"url"`
+	SubmitCount      int        `json:"submit_count"`
+	FirstSubmittedAt time.Time  `json:"first_submitted_at"`
+	LastSubmittedAt  time.Time  `json:"last_submitted_at"`
+	LastJobID        string     `json:"last_job_id,omitempty"`
+	LastStatus       string     `json:"last_status,omitempty"`
+	LastScore        int        `json:"last_score"`
+	LastTitle        string     `json:"last_title,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+	LastAnalyzedAt   *time.Time `json:"last_analyzed_at,omitempty"`
+}
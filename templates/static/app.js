@@ -0,0 +1,34 @@
+// Keeps elements with a data-refresh attribute up to date by reloading their
+// content from the given URL every few seconds.
+(function () {
+    "use strict";
+
+    var interval = 2000;
+
+    function refresh(el) {
+        fetch(el.dataset.refresh, { credentials: "same-origin" })
+            .then(function (resp) {
+                if (resp.redirected || !resp.ok) {
+                    // The session expired: reload to reach the sign in page.
+                    window.location.reload();
+                    return null;
+                }
+                return resp.text();
+            })
+            .then(function (html) {
+                if (html !== null) {
+                    el.innerHTML = html;
+                }
+            })
+            .catch(function () {})
+            .finally(function () {
+                setTimeout(function () { refresh(el); }, interval);
+            });
+    }
+
+    document.addEventListener("DOMContentLoaded", function () {
+        document.querySelectorAll("[data-refresh]").forEach(function (el) {
+            setTimeout(function () { refresh(el); }, interval);
+        });
+    });
+})();
@@ -0,0 +1,89 @@
+// Package templates embeds the dashboard's HTML templates and static assets, so
+// the server binary is self-contained.
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+//go:embed *.html
+var pages embed.FS
+
+//go:embed static
+var static embed.FS
+
+// Funcs are the functions available to the templates.
+var Funcs = template.FuncMap{
+	"formatTime":  formatTime,
+	"formatBytes": formatBytes,
+	"statusClass": statusClass,
+	"minus":       func(a, b int) int { return a - b },
+}
+
+// Parse parses the page templates. Each page is named after its file, e.g.
+// "index.html".
+func Parse() (*template.Template, error) {
+	return template.New("").Funcs(Funcs).ParseFS(pages, "*.html")
+}
+
+// Static returns the static assets served under /ui/static/.
+func Static() fs.FS {
+	sub, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// formatTime formats a time.Time or *time.Time for display. Zero and nil times
+// render as an empty string.
+func formatTime(v any) string {
+	var t time.Time
+	switch v := v.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	}
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+// formatBytes formats a size in bytes with a binary unit, e.g. "1.5 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// statusClass returns the CSS class of a job or analysis status, or of the
+// rating of a security header.
+func statusClass(status any) string {
+	switch s := strings.ToLower(fmt.Sprint(status)); s {
+	case "completed", "failed", "queued":
+		return "status-" + s
+	case "in progress", "weak":
+		return "status-running"
+	case "good":
+		return "status-completed"
+	case "missing":
+		return "status-failed"
+	}
+	return "status-unknown"
+}
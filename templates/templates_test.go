@@ -0,0 +1,50 @@
+package templates
+
+import (
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"index.html", "jobs.html", "result.html", "history.html", "diff.html", "login.html", "error.html"} {
+		if tmpl.Lookup(name) == nil {
+			t.Errorf("expected template %s", name)
+		}
+	}
+}
+
+func TestStatic(t *testing.T) {
+	for _, name := range []string{"style.css", "app.js"} {
+		if _, err := fs.Stat(Static(), name); err != nil {
+			t.Errorf("expected static asset %s: %v", name, err)
+		}
+	}
+}
+
+func TestFuncs(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	var unset *time.Time
+	tests := []struct {
+		got, want string
+	}{
+		{formatTime(at), "2024-05-01 12:30:00 UTC"},
+		{formatTime(&at), "2024-05-01 12:30:00 UTC"},
+		{formatTime(unset), ""},
+		{formatBytes(512), "512 B"},
+		{formatBytes(1536), "1.5 KiB"},
+		{formatBytes(5 << 20), "5.0 MiB"},
+		{statusClass("Completed"), "status-completed"},
+		{statusClass("In progress"), "status-running"},
+		{statusClass("Unknown"), "status-unknown"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q; want %q", tt.got, tt.want)
+		}
+	}
+}